@@ -104,6 +104,22 @@ func verifyStatusAbortMultipartUpload(respStatusCode, expectedStatusCode int) er
 // AWS maintains the uploadIDs for several hours there is no sure way to test for the right error messages.  // As of now though it is known there is a bug within the Minio Server that returns a shortened form of the
 // error AWS is said to return.
 
+// abortMultipartUpload - Abort a multipart upload and every part uploaded to it.
+func abortMultipartUpload(config ServerConfig, bucketName, objectName, uploadID string) error {
+	req, err := newAbortMultipartUploadReq(bucketName, objectName, uploadID)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return abortMultipartUploadVerify(res, http.StatusNoContent, ErrorResponse{})
+}
+
 // mainAbortMultipartUpload - abort multipart upload API test.
 func mainAbortMultipartUpload(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] Multipart (Abort Upload):", curTest, globalTotalNumTest)
@@ -0,0 +1,115 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Object ownership settings.
+const (
+	objectOwnershipObjectWriter         = "ObjectWriter"
+	objectOwnershipBucketOwnerPreferred = "BucketOwnerPreferred"
+	objectOwnershipBucketOwnerEnforced  = "BucketOwnerEnforced"
+)
+
+// The only canned ACL accepted once ACLs are disabled by BucketOwnerEnforced.
+const bucketOwnerFullControlACL = "bucket-owner-full-control"
+
+var (
+	// The bucket holding the ownership controls and public access block set by s3verify.
+	s3verifyAccessBucket BucketInfo
+
+	// Holds all objects uploaded to the access control bucket.
+	accessObjects = []*ObjectInfo{}
+)
+
+// verifyAccessControlResponse - Verify the status of a response and, when one is expected, its error code.
+func verifyAccessControlResponse(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if expectedError.Code != "" {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	return verifyStandardHeaders(res.Header)
+}
+
+// verifyEmptyBody - Verify that a successful configuration request returned no body.
+func verifyEmptyBody(res *http.Response) error {
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) != 0 {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// putAccessObject - Upload an object with a canned ACL to the access control bucket and verify the response.
+func putAccessObject(config ServerConfig, object *ObjectInfo, acl string, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutObjectCannedACLReq(s3verifyAccessBucket.Name, object.Key, object.Body, acl)
+	if err != nil {
+		return err
+	}
+	// Save the object so it is removed later, even if the server wrongly accepted it.
+	accessObjects = append(accessObjects, object)
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		err := fmt.Errorf("PUT %s (%s): %v", object.Key, acl, err)
+		return err
+	}
+	return nil
+}
+
+// putAccessBucketACL - Set a canned ACL on the access control bucket and verify the response.
+func putAccessBucketACL(config ServerConfig, acl string, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutBucketACLReq(s3verifyAccessBucket.Name, acl, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		return fmt.Errorf("PUT bucket ACL (%s): %v", acl, err)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Header used to set a canned ACL.
+const aclHeader = "X-Amz-Acl"
+
+// Grantee types.
+const (
+	granteeCanonicalUser = "CanonicalUser"
+	granteeGroup         = "Group"
+)
+
+// Predefined groups that can be given permissions.
+const (
+	allUsersURI           = "http://acs.amazonaws.com/groups/global/AllUsers"
+	authenticatedUsersURI = "http://acs.amazonaws.com/groups/global/AuthenticatedUsers"
+)
+
+// Permissions that can be granted.
+const (
+	permissionFullControl = "FULL_CONTROL"
+	permissionRead        = "READ"
+	permissionWrite       = "WRITE"
+)
+
+// cannedACL - A canned ACL along with the grants it gives on top of the owner's FULL_CONTROL.
+type cannedACL struct {
+	name   string
+	grants []grant
+}
+
+// Canned ACLs that apply to both buckets and objects.
+var cannedACLs = []cannedACL{
+	cannedACL{
+		name: "private",
+	},
+	cannedACL{
+		name: "public-read",
+		grants: []grant{
+			newGroupGrant(allUsersURI, permissionRead),
+		},
+	},
+	cannedACL{
+		name: "public-read-write",
+		grants: []grant{
+			newGroupGrant(allUsersURI, permissionRead),
+			newGroupGrant(allUsersURI, permissionWrite),
+		},
+	},
+	cannedACL{
+		name: "authenticated-read",
+		grants: []grant{
+			newGroupGrant(authenticatedUsersURI, permissionRead),
+		},
+	},
+}
+
+var (
+	// The bucket created with a canned ACL.
+	s3verifyACLBucket BucketInfo
+
+	// Holds all objects uploaded to the ACL bucket.
+	aclObjects = []*ObjectInfo{}
+)
+
+// newGroupGrant - Create a grant of permission to a predefined group.
+func newGroupGrant(uri, permission string) grant {
+	return grant{
+		Grantee: grantee{
+			Type: granteeGroup,
+			URI:  uri,
+		},
+		Permission: permission,
+	}
+}
+
+// newOwnerGrant - Create the FULL_CONTROL grant every owner holds.
+func newOwnerGrant(aclOwner owner) grant {
+	return grant{
+		Grantee: grantee{
+			Type: granteeCanonicalUser,
+			ID:   aclOwner.ID,
+		},
+		Permission: permissionFullControl,
+	}
+}
+
+// cannedACLGrants - The full list of grants a canned ACL gives for an owner.
+func cannedACLGrants(aclOwner owner, acl cannedACL) []grant {
+	return append([]grant{newOwnerGrant(aclOwner)}, acl.grants...)
+}
+
+// grantsMatch - Display names are optional so grantees are matched on their ID or URI only.
+func grantsMatch(a, b grant) bool {
+	return a.Permission == b.Permission &&
+		a.Grantee.Type == b.Grantee.Type &&
+		a.Grantee.ID == b.Grantee.ID &&
+		a.Grantee.URI == b.Grantee.URI
+}
+
+// verifyAccessControlPolicy - Verify that an ACL holds exactly the expected owner and grants, in any order.
+func verifyAccessControlPolicy(received accessControlPolicy, expectedOwner owner, expectedGrants []grant) error {
+	if received.Owner.ID != expectedOwner.ID {
+		err := fmt.Errorf("Unexpected Owner ID Received: wanted %s, got %s", expectedOwner.ID, received.Owner.ID)
+		return err
+	}
+	receivedGrants := received.AccessControlList.Grants
+	if len(receivedGrants) != len(expectedGrants) {
+		err := fmt.Errorf("Unexpected Grants Received: wanted %v, got %v", expectedGrants, receivedGrants)
+		return err
+	}
+	for _, expectedGrant := range expectedGrants {
+		found := false
+		for _, receivedGrant := range receivedGrants {
+			if grantsMatch(expectedGrant, receivedGrant) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			err := fmt.Errorf("Missing Grant: wanted %v in %v", expectedGrant, receivedGrants)
+			return err
+		}
+	}
+	return nil
+}
+
+// isACLNotImplemented - Servers that do not support an ACL must refuse it with NotImplemented instead of ignoring it.
+func isACLNotImplemented(res *http.Response) (bool, error) {
+	if res.StatusCode != http.StatusNotImplemented {
+		return false, nil
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return false, err
+	}
+	if receivedError.Code != "NotImplemented" {
+		err := fmt.Errorf("Unexpected Error Code: wanted NotImplemented, got %s", receivedError.Code)
+		return false, err
+	}
+	return true, nil
+}
+
+// getCanonicalOwner - The owner reported by ListBuckets owns every bucket and object s3verify creates.
+func getCanonicalOwner(config ServerConfig) (owner, error) {
+	req, err := newListBucketsReq()
+	if err != nil {
+		return owner{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return owner{}, err
+	}
+	defer closeResponse(res)
+	if err := verifyStatusListBuckets(res.StatusCode, http.StatusOK); err != nil {
+		return owner{}, err
+	}
+	result := listAllMyBucketsResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return owner{}, err
+	}
+	return result.Owner, nil
+}
+
+// isPublicRead - Whether a canned ACL lets anyone read.
+func isPublicRead(acl cannedACL) bool {
+	for _, aclGrant := range acl.grants {
+		if grantsMatch(aclGrant, newGroupGrant(allUsersURI, permissionRead)) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyAnonymousGetObject - Verify that an unsigned GET is only allowed on publicly readable objects.
+func verifyAnonymousGetObject(config ServerConfig, bucketName string, object *ObjectInfo, public bool) error {
+	req, err := newGetObjectReq(bucketName, object.Key, nil)
+	if err != nil {
+		return err
+	}
+	req.anonymous = true
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	if public {
+		return getObjectVerify(res, object.Body, http.StatusOK, nil)
+	}
+	if err := verifyStatusGetObject(res.StatusCode, http.StatusForbidden); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != "AccessDenied" {
+		err := fmt.Errorf("Unexpected Error Code: wanted AccessDenied, got %s", receivedError.Code)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// How long to wait for the expected events, and how long to keep listening for unexpected ones.
+const (
+	notificationTimeout     = 30 * time.Second
+	notificationQuietPeriod = 3 * time.Second
+)
+
+// Holds all objects created by the bucket notification tests.
+var notificationObjects = []*ObjectInfo{}
+
+// webhookListener - A local HTTP server receiving the notifications sent to the webhook target.
+type webhookListener struct {
+	listener net.Listener
+	records  chan eventRecord
+}
+
+// startWebhookListener - Start receiving notifications on the given address.
+func startWebhookListener(address string) (*webhookListener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	webhook := &webhookListener{
+		listener: listener,
+		records:  make(chan eventRecord, 100),
+	}
+	go http.Serve(listener, webhook)
+	return webhook, nil
+}
+
+// ServeHTTP - Queue the records of every notification received.
+func (w *webhookListener) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	event := notificationEvent{}
+	// Servers check that the target is reachable with requests carrying no records.
+	if err := json.NewDecoder(r.Body).Decode(&event); err == nil {
+		for _, record := range event.Records {
+			// Records beyond the buffer are dropped rather than blocking the server.
+			select {
+			case w.records <- record:
+			default:
+			}
+		}
+	}
+	rw.WriteHeader(http.StatusOK)
+}
+
+// Close - Stop receiving notifications.
+func (w *webhookListener) Close() error {
+	return w.listener.Close()
+}
+
+// waitForRecords - Wait for exactly numRecords records, failing on any record received after them.
+func (w *webhookListener) waitForRecords(numRecords int) ([]eventRecord, error) {
+	records := []eventRecord{}
+	timeout := time.After(notificationTimeout)
+	for len(records) < numRecords {
+		select {
+		case record := <-w.records:
+			records = append(records, record)
+		case <-timeout:
+			err := fmt.Errorf("Timed Out Waiting for Notifications: wanted %d records, got %d", numRecords, len(records))
+			return records, err
+		}
+	}
+	select {
+	case record := <-w.records:
+		err := fmt.Errorf("Unexpected Notification Received: %s for %s", record.EventName, record.S3.Object.Key)
+		return records, err
+	case <-time.After(notificationQuietPeriod):
+	}
+	return records, nil
+}
+
+// expectedRecord - An event s3verify expects to be notified of.
+type expectedRecord struct {
+	eventName string
+	key       string
+	// Size and ETag are only sent for created objects.
+	size int64
+	etag string
+}
+
+// findRecord - Find the record of the expected event, servers may deliver events out of order.
+func findRecord(records []eventRecord, bucketName string, expected expectedRecord) (eventRecord, error) {
+	for _, record := range records {
+		// Servers differ in whether event names carry the s3: prefix.
+		if strings.TrimPrefix(record.EventName, "s3:") != expected.eventName {
+			continue
+		}
+		// Keys are sent URL encoded.
+		key, err := url.QueryUnescape(record.S3.Object.Key)
+		if err != nil {
+			return eventRecord{}, err
+		}
+		if key != expected.key {
+			continue
+		}
+		if record.S3.Bucket.Name != bucketName {
+			err := fmt.Errorf("Unexpected Bucket Notified for %s %s: wanted %s, got %s", expected.eventName, expected.key, bucketName, record.S3.Bucket.Name)
+			return eventRecord{}, err
+		}
+		if record.S3.Object.Sequencer == "" {
+			err := fmt.Errorf("Unexpected Sequencer Received for %s %s: wanted a sequencer, got none", expected.eventName, expected.key)
+			return eventRecord{}, err
+		}
+		if expected.etag == "" {
+			return record, nil
+		}
+		if record.S3.Object.Size != expected.size {
+			err := fmt.Errorf("Unexpected Size Received for %s %s: wanted %d, got %d", expected.eventName, expected.key, expected.size, record.S3.Object.Size)
+			return eventRecord{}, err
+		}
+		if etag := strings.Trim(record.S3.Object.ETag, "\""); etag != expected.etag {
+			err := fmt.Errorf("Unexpected ETag Received for %s %s: wanted %s, got %s", expected.eventName, expected.key, expected.etag, etag)
+			return eventRecord{}, err
+		}
+		return record, nil
+	}
+	err := fmt.Errorf("Missing Notification: no %s event received for %s", expected.eventName, expected.key)
+	return eventRecord{}, err
+}
+
+// sequencerBefore - Sequencers of events on the same key are compared as hexadecimal
+// strings, left padding the shorter one with zeros.
+func sequencerBefore(a, b string) bool {
+	for len(a) < len(b) {
+		a = "0" + a
+	}
+	for len(b) < len(a) {
+		b = "0" + b
+	}
+	return strings.ToUpper(a) < strings.ToUpper(b)
+}
+
+// mainBucketNotificationEvents - Entry point for the test receiving bucket notifications on a local webhook.
+func mainBucketNotificationEvents(config ServerConfig, curTest int) bool {
+	// Only servers with a webhook target are tested, the test name reports when the test is skipped.
+	testName := "BucketNotification (Webhook Events)"
+	if config.WebhookARN == "" {
+		testName = "BucketNotification (Webhook, Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	if config.WebhookARN == "" {
+		// Test skipped.
+		printMessage(message, nil)
+		return true
+	}
+	bucketName := s3verifyBuckets[0].Name
+	webhook, err := startWebhookListener(config.WebhookAddress)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer webhook.Close()
+	if err := putBucketNotification(config, bucketName, newS3verifyNotification(config.WebhookARN), http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Stop sending events once the test is over, the listener is closed with it.
+	defer putBucketNotification(config, bucketName, notificationConfiguration{}, http.StatusOK, ErrorResponse{})
+	// Spin scanBar
+	scanBar(message)
+	put := &ObjectInfo{
+		Key:  notificationPrefix + "put" + notificationSuffix,
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	copied := &ObjectInfo{
+		Key:  notificationPrefix + "copy" + notificationSuffix,
+		Body: put.Body,
+	}
+	multipart := &ObjectInfo{
+		Key:  notificationPrefix + "multipart" + notificationSuffix,
+		Body: []byte(randString(1024, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	// Neither object matches the filter so no events are sent for them.
+	filtered := []*ObjectInfo{
+		&ObjectInfo{
+			Key:  notificationPrefix + "ignored.bin",
+			Body: put.Body,
+		},
+		&ObjectInfo{
+			Key:  "s3verify/ignored" + notificationSuffix,
+			Body: put.Body,
+		},
+	}
+	// Save the objects so they are removed later.
+	notificationObjects = append(notificationObjects, put, copied, multipart)
+	notificationObjects = append(notificationObjects, filtered...)
+	for _, object := range append([]*ObjectInfo{put}, filtered...) {
+		if err := putObject(config, bucketName, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	req, err := newCopyObjectReq(bucketName, put.Key, bucketName, copied.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	if err := copyObjectVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := putMultipartObject(config, bucketName, multipart.Key, [][]byte{multipart.Body}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := removeObject(config, bucketName, put.Key); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	putMD5 := md5.Sum(put.Body)
+	expectedRecords := []expectedRecord{
+		{"ObjectCreated:Put", put.Key, int64(len(put.Body)), hex.EncodeToString(putMD5[:])},
+		{"ObjectCreated:Copy", copied.Key, int64(len(copied.Body)), hex.EncodeToString(putMD5[:])},
+		{"ObjectCreated:CompleteMultipartUpload", multipart.Key, int64(len(multipart.Body)), multipartETag([][]byte{multipart.Body})},
+		{"ObjectRemoved:Delete", put.Key, 0, ""},
+	}
+	records, err := webhook.waitForRecords(len(expectedRecords))
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	received := []eventRecord{}
+	for _, expected := range expectedRecords {
+		record, err := findRecord(records, bucketName, expected)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		received = append(received, record)
+	}
+	// Events on the same key are ordered by their sequencer.
+	if created, removed := received[0].S3.Object.Sequencer, received[3].S3.Object.Sequencer; !sequencerBefore(created, removed) {
+		err := fmt.Errorf("Unexpected Sequencer Order for %s: ObjectCreated:Put has %s, ObjectRemoved:Delete has %s", put.Key, created, removed)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,198 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"hash/crc64"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Prefix of the header carrying the checksum of an object or part, e.g. X-Amz-Checksum-Crc32.
+	checksumHeaderPrefix = "X-Amz-Checksum-"
+	// Names the algorithm used for the checksum sent with a request.
+	sdkChecksumAlgorithmHeader = "X-Amz-Sdk-Checksum-Algorithm"
+	// Names the algorithm used for the parts of a multipart upload.
+	checksumAlgorithmHeader = "X-Amz-Checksum-Algorithm"
+	// Names whether the checksum of a multipart upload is a composite of its parts or covers the full object.
+	checksumTypeHeader = "X-Amz-Checksum-Type"
+	// Asks GET and HEAD requests to return the stored checksum.
+	checksumModeHeader = "X-Amz-Checksum-Mode"
+	// Payload hash used when the body is aws-chunked encoded with an unsigned trailer.
+	unsignedPayloadTrailer = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+)
+
+// checksumAlgorithm - An additional checksum algorithm supported by S3.
+type checksumAlgorithm struct {
+	name    string
+	newHash func() hash.Hash
+	// Full object checksums can not be used to build a composite checksum of the parts.
+	fullObject bool
+}
+
+// All additional checksum algorithms s3verify tests.
+var checksumAlgorithms = []checksumAlgorithm{
+	checksumAlgorithm{
+		name:    "CRC32",
+		newHash: func() hash.Hash { return crc32.NewIEEE() },
+	},
+	checksumAlgorithm{
+		name:    "CRC32C",
+		newHash: func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) },
+	},
+	checksumAlgorithm{
+		name:    "SHA1",
+		newHash: sha1.New,
+	},
+	checksumAlgorithm{
+		name:    "SHA256",
+		newHash: sha256.New,
+	},
+	checksumAlgorithm{
+		name:       "CRC64NVME",
+		newHash:    func() hash.Hash { return crc64.New(crc64.MakeTable(0x9a6c9329ac4bc9b5)) },
+		fullObject: true,
+	},
+}
+
+// header - The header carrying a checksum of this algorithm.
+func (a checksumAlgorithm) header() string {
+	return http.CanonicalHeaderKey(checksumHeaderPrefix + a.name)
+}
+
+// sum - The raw checksum of data.
+func (a checksumAlgorithm) sum(data []byte) []byte {
+	h := a.newHash()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// checksum - The base64 encoded checksum of data as sent in headers.
+func (a checksumAlgorithm) checksum(data []byte) string {
+	return base64.StdEncoding.EncodeToString(a.sum(data))
+}
+
+// compositeChecksum - The checksum of a multipart object, the checksum of the
+// concatenated raw part checksums followed by the number of parts.
+func (a checksumAlgorithm) compositeChecksum(parts [][]byte) string {
+	h := a.newHash()
+	for _, part := range parts {
+		h.Write(a.sum(part))
+	}
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(parts))
+}
+
+// multipartChecksum - The checksum of a multipart object, full object checksums
+// cover the whole object as if it was uploaded at once.
+func (a checksumAlgorithm) multipartChecksum(parts [][]byte) string {
+	if a.fullObject {
+		return a.checksum(bytes.Join(parts, nil))
+	}
+	return a.compositeChecksum(parts)
+}
+
+// get - The checksum stored for the given algorithm.
+func (c objectChecksums) get(a checksumAlgorithm) string {
+	switch a.name {
+	case "CRC32":
+		return c.ChecksumCRC32
+	case "CRC32C":
+		return c.ChecksumCRC32C
+	case "SHA1":
+		return c.ChecksumSHA1
+	case "SHA256":
+		return c.ChecksumSHA256
+	case "CRC64NVME":
+		return c.ChecksumCRC64NVME
+	}
+	return ""
+}
+
+// set - Store the checksum for the given algorithm.
+func (c *objectChecksums) set(a checksumAlgorithm, checksum string) {
+	switch a.name {
+	case "CRC32":
+		c.ChecksumCRC32 = checksum
+	case "CRC32C":
+		c.ChecksumCRC32C = checksum
+	case "SHA1":
+		c.ChecksumSHA1 = checksum
+	case "SHA256":
+		c.ChecksumSHA256 = checksum
+	case "CRC64NVME":
+		c.ChecksumCRC64NVME = checksum
+	}
+}
+
+// setTrailingChecksum - Rewrite the body of a request as aws-chunked with the
+// checksum sent in a trailer after the data instead of in the headers.
+func setTrailingChecksum(req *Request, data []byte, a checksumAlgorithm, checksum string) {
+	trailer := strings.ToLower(a.header())
+	var body bytes.Buffer
+	if len(data) > 0 {
+		fmt.Fprintf(&body, "%x\r\n", len(data))
+		body.Write(data)
+		body.WriteString("\r\n")
+	}
+	fmt.Fprintf(&body, "0\r\n%s:%s\r\n\r\n", trailer, checksum)
+
+	// The checksum moves to the trailer and the MD5 sum only covers the decoded data.
+	req.customHeader.Del(a.header())
+	req.customHeader.Del("Content-MD5")
+	req.customHeader.Set("X-Amz-Content-Sha256", unsignedPayloadTrailer)
+	req.customHeader.Set("Content-Encoding", "aws-chunked")
+	req.customHeader.Set("X-Amz-Decoded-Content-Length", strconv.Itoa(len(data)))
+	req.customHeader.Set("X-Amz-Trailer", trailer)
+	req.customHeader.Set(sdkChecksumAlgorithmHeader, a.name)
+	req.contentLength = int64(body.Len())
+	req.contentBody = bytes.NewReader(body.Bytes())
+}
+
+// verifyChecksumHeader - Verify that the checksum returned matches what is expected.
+func verifyChecksumHeader(header http.Header, a checksumAlgorithm, expectedChecksum string) error {
+	if checksum := header.Get(a.header()); checksum != expectedChecksum {
+		err := fmt.Errorf("Unexpected %s Received: wanted %v, got %v", a.header(), expectedChecksum, checksum)
+		return err
+	}
+	return nil
+}
+
+// verifyChecksumErrorResponse - Verify a request failed with the expected status and error code.
+func verifyChecksumErrorResponse(res *http.Response, expectedStatusCode int, expectedCode string) error {
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedCode {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedCode, receivedError.Code)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// newCompleteMultipartUploadConditionalReq - Create a new Request for complete-multipart API with a precondition header.
+func newCompleteMultipartUploadConditionalReq(bucketName, objectName, uploadID string, complete *completeMultipartUpload, header, value string) (Request, error) {
+	completeMultipartUploadReq, err := newCompleteMultipartUploadReq(bucketName, objectName, uploadID, complete)
+	if err != nil {
+		return Request{}, err
+	}
+	completeMultipartUploadReq.customHeader.Set(header, value)
+	return completeMultipartUploadReq, nil
+}
+
+// completeMultipartUploadConditional - Complete a multipart upload with a precondition header and return the ETag of the new object.
+// A non empty expectedError code means the precondition must not be met.
+func completeMultipartUploadConditional(config ServerConfig, bucketName, objectName, uploadID string, complete *completeMultipartUpload, header, value string, expectedStatusCode int, expectedError ErrorResponse) (string, error) {
+	req, err := newCompleteMultipartUploadConditionalReq(bucketName, objectName, uploadID, complete, header, value)
+	if err != nil {
+		return "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if expectedError.Code != "" {
+		return "", verifyMultipartErrorResponse(res, expectedStatusCode, expectedError)
+	}
+	if err := verifyStatusCompleteMultipartUpload(res.StatusCode, expectedStatusCode); err != nil {
+		return "", err
+	}
+	result := completeMultipartUploadResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return "", err
+	}
+	return result.ETag, nil
+}
+
+// uploadSinglePart - Start a multipart upload of a single part and return the upload and the parts to complete it with.
+// The upload is aborted when the part can not be uploaded so no upload is left behind.
+func uploadSinglePart(config ServerConfig, bucketName, objectName string, partData []byte) (string, *completeMultipartUpload, error) {
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		return "", nil, err
+	}
+	partETag, err := uploadPart(config, bucketName, objectName, uploadID, 1, partData)
+	if err != nil {
+		abortMultipartUpload(config, bucketName, objectName, uploadID)
+		return "", nil, err
+	}
+	complete := &completeMultipartUpload{
+		Parts: []completePart{{PartNumber: 1, ETag: partETag}},
+	}
+	return uploadID, complete, nil
+}
+
+// mainCompleteMultipartUploadConditional - Entry point for the CompleteMultipartUpload If-None-Match and If-Match test.
+func mainCompleteMultipartUploadConditional(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Conditional Complete-Upload):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objects := []*ObjectInfo{}
+	for i := 0; i < 2; i++ {
+		// A single part upload may be smaller than 5MB.
+		object := &ObjectInfo{
+			Key:  "s3verify/conditional/multipart",
+			Body: make([]byte, 1024),
+		}
+		if _, err := io.ReadFull(crand.Reader, object.Body); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		objects = append(objects, object)
+	}
+	// Save the object so it is removed later.
+	conditionalObjects = append(conditionalObjects, objects[0])
+	preconditionFailed := ErrorResponse{Code: "PreconditionFailed"}
+	// Both objects share a key, the upload still open is aborted if the test fails before completing it.
+	openUploadID := ""
+	defer func() {
+		if openUploadID != "" {
+			abortMultipartUpload(config, bucketName, objects[0].Key, openUploadID)
+		}
+	}()
+	// Spin scanBar
+	scanBar(message)
+	// Create-only succeeds while the key does not exist.
+	uploadID, complete, err := uploadSinglePart(config, bucketName, objects[0].Key, objects[0].Body)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	openUploadID = uploadID
+	firstETag, err := completeMultipartUploadConditional(config, bucketName, objects[0].Key, uploadID, complete, "If-None-Match", "*", http.StatusOK, ErrorResponse{})
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	openUploadID = ""
+	// Spin scanBar
+	scanBar(message)
+	// A second upload to the same key must not overwrite it with create-only.
+	uploadID, complete, err = uploadSinglePart(config, bucketName, objects[1].Key, objects[1].Body)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	openUploadID = uploadID
+	if _, err := completeMultipartUploadConditional(config, bucketName, objects[1].Key, uploadID, complete, "If-None-Match", "*", http.StatusPreconditionFailed, preconditionFailed); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Nor with an ETag that was never current.
+	if _, err := completeMultipartUploadConditional(config, bucketName, objects[1].Key, uploadID, complete, "If-Match", "\"1234567890\"", http.StatusPreconditionFailed, preconditionFailed); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectBody(config, bucketName, objects[0]); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// A failed precondition leaves the upload in place, so it can still be completed with the current ETag.
+	if _, err := completeMultipartUploadConditional(config, bucketName, objects[1].Key, uploadID, complete, "If-Match", firstETag, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	openUploadID = ""
+	if err := verifyObjectBody(config, bucketName, objects[1]); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
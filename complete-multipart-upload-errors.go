@@ -0,0 +1,136 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// verifyMultipartErrorResponse - Verify that a multipart request failed as expected.
+func verifyMultipartErrorResponse(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if err := verifyStatusCompleteMultipartUpload(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// completeMultipartUploadError - Complete a multipart upload that is expected to fail.
+func completeMultipartUploadError(config ServerConfig, bucketName, objectName, uploadID string, complete *completeMultipartUpload, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newCompleteMultipartUploadReq(bucketName, objectName, uploadID, complete)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the request failed as expected.
+	return verifyMultipartErrorResponse(res, expectedStatusCode, expectedError)
+}
+
+// mainCompleteMultipartUploadErrors - Entry point for the complete multipart upload test with invalid part lists.
+func mainCompleteMultipartUploadErrors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Complete-Upload Errors):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objectName := "s3verify/multipart/complete-errors"
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before aborting it itself.
+	aborted := false
+	defer func() {
+		if !aborted {
+			abortMultipartUpload(config, bucketName, objectName, uploadID)
+		}
+	}()
+	// Upload a 5MB part followed by two parts below the minimum part size.
+	partETags := []string{}
+	for i, partSize := range []int{5 * 1024 * 1024, 1024, 1024} {
+		// Spin scanBar
+		scanBar(message)
+		partData := make([]byte, partSize)
+		if _, err := io.ReadFull(crand.Reader, partData); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partETag, err := uploadPart(config, bucketName, objectName, uploadID, i+1, partData)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partETags = append(partETags, partETag)
+	}
+	testCases := []struct {
+		uploadID           string
+		parts              []completePart
+		expectedStatusCode int
+		expectedError      ErrorResponse
+	}{
+		// Only the last part may be smaller than 5MB.
+		{uploadID, []completePart{{PartNumber: 2, ETag: partETags[1]}, {PartNumber: 3, ETag: partETags[2]}}, http.StatusBadRequest, ErrorResponse{Code: "EntityTooSmall"}},
+		// Parts must be listed in ascending order.
+		{uploadID, []completePart{{PartNumber: 2, ETag: partETags[1]}, {PartNumber: 1, ETag: partETags[0]}}, http.StatusBadRequest, ErrorResponse{Code: "InvalidPartOrder"}},
+		// The ETag of every part must match the part uploaded.
+		{uploadID, []completePart{{PartNumber: 1, ETag: partETags[2]}, {PartNumber: 3, ETag: partETags[2]}}, http.StatusBadRequest, ErrorResponse{Code: "InvalidPart"}},
+		// The upload must exist.
+		{"s3verify-upload-id", []completePart{{PartNumber: 1, ETag: partETags[0]}, {PartNumber: 3, ETag: partETags[2]}}, http.StatusNotFound, ErrorResponse{Code: "NoSuchUpload"}},
+	}
+	for _, testCase := range testCases {
+		// Spin scanBar
+		scanBar(message)
+		complete := &completeMultipartUpload{
+			Parts: testCase.parts,
+		}
+		if err := completeMultipartUploadError(config, bucketName, objectName, testCase.uploadID, complete, testCase.expectedStatusCode, testCase.expectedError); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// None of the attempts completed the upload so it can still be aborted.
+	if err := abortMultipartUpload(config, bucketName, objectName, uploadID); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	aborted = true
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,181 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// multipartETag - The ETag of a multipart object is the MD5 of the concatenated MD5s of its parts followed by -N.
+func multipartETag(parts [][]byte) string {
+	md5s := []byte{}
+	for _, part := range parts {
+		partMD5 := md5.Sum(part)
+		md5s = append(md5s, partMD5[:]...)
+	}
+	etag := md5.Sum(md5s)
+	return hex.EncodeToString(etag[:]) + "-" + strconv.Itoa(len(parts))
+}
+
+// completeMultipartUploadETagVerify - Verify that the response returned holds the expected ETag.
+func completeMultipartUploadETagVerify(res *http.Response, expectedStatusCode int, expectedETag string) error {
+	if err := verifyStatusCompleteMultipartUpload(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderCompleteMultipartUpload(res.Header); err != nil {
+		return err
+	}
+	result := completeMultipartUploadResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return err
+	}
+	if etag := strings.Trim(result.ETag, "\""); etag != expectedETag {
+		err := fmt.Errorf("Unexpected ETag Received: wanted %s, got %s", expectedETag, etag)
+		return err
+	}
+	return nil
+}
+
+// mainCompleteMultipartUploadETag - Entry point for the complete multipart upload test of the final ETag.
+func mainCompleteMultipartUploadETag(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Complete-Upload ETag):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	object := &ObjectInfo{
+		Key: "s3verify/multipart/etag",
+	}
+	var err error
+	object.UploadID, err = initiateMultipartUpload(config, bucketName, object.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before completing it.
+	completed := false
+	defer func() {
+		if !completed {
+			abortMultipartUpload(config, bucketName, object.Key, object.UploadID)
+		}
+	}()
+	// Upload the first part twice, the second upload replaces the first, then a smaller last part.
+	parts := [][]byte{}
+	partNumbers := []int{1, 1, 2}
+	partETags := []string{}
+	for i, partSize := range []int{5 * 1024 * 1024, 5 * 1024 * 1024, 1024} {
+		// Spin scanBar
+		scanBar(message)
+		partData := make([]byte, partSize)
+		if _, err := io.ReadFull(crand.Reader, partData); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partETag, err := uploadPart(config, bucketName, object.Key, object.UploadID, partNumbers[i], partData)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		parts = append(parts, partData)
+		partETags = append(partETags, partETag)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The replaced part can no longer be completed.
+	replaced := &completeMultipartUpload{
+		Parts: []completePart{
+			{PartNumber: 1, ETag: partETags[0]},
+			{PartNumber: 2, ETag: partETags[2]},
+		},
+	}
+	if err := completeMultipartUploadError(config, bucketName, object.Key, object.UploadID, replaced, http.StatusBadRequest, ErrorResponse{Code: "InvalidPart"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Complete with the parts that replaced the first one.
+	complete := &completeMultipartUpload{
+		Parts: []completePart{
+			{PartNumber: 1, ETag: partETags[1]},
+			{PartNumber: 2, ETag: partETags[2]},
+		},
+	}
+	expectedETag := multipartETag(parts[1:])
+	req, err := newCompleteMultipartUploadReq(bucketName, object.Key, object.UploadID, complete)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := completeMultipartUploadETagVerify(res, http.StatusOK, expectedETag); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	completed = true
+	// Save the object so it is removed later.
+	object.Body = append(append([]byte{}, parts[1]...), parts[2]...)
+	object.PartSizes = []int64{int64(len(parts[1])), int64(len(parts[2]))}
+	multipartObjects = append(multipartObjects, object)
+	// Spin scanBar
+	scanBar(message)
+	// The object must report the same ETag and hold the replacing part.
+	headReq, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	headRes, err := config.execRequest("HEAD", headReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(headRes)
+	// Verify the response.
+	if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if etag := strings.Trim(headRes.Header.Get("ETag"), "\""); etag != expectedETag {
+		err := fmt.Errorf("Unexpected ETag Received: wanted %s, got %s", expectedETag, etag)
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectBody(config, bucketName, object); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
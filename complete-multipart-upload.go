@@ -62,8 +62,6 @@ func newCompleteMultipartUploadReq(bucketName, objectName, uploadID string, comp
 	return completeMultipartUploadReq, nil
 }
 
-// TODO: So far only valid multipart requests are used. Implement tests that SHOULD fail.
-//
 // completeMultipartUploadVerify - verify tthat the response returned matches what is expected.
 func completeMultipartUploadVerify(res *http.Response, expectedStatusCode int) error {
 	if err := verifyStatusCompleteMultipartUpload(res.StatusCode, expectedStatusCode); err != nil {
@@ -133,6 +131,8 @@ func mainCompleteMultipartUpload(config ServerConfig, curTest int) bool {
 		printMessage(message, err)
 		return false
 	}
+	// The object was uploaded as a single part.
+	object.PartSizes = []int64{int64(len(object.Body))}
 	// Spin scanBar
 	scanBar(message)
 	printMessage(message, nil)
@@ -0,0 +1,233 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// conditionalCase - A combination of precondition headers and the status a GET must return for it.
+type conditionalCase struct {
+	name   string
+	header http.Header
+	// HEAD returns the same status, CopyObject fails a 304 with 412 instead.
+	expectedStatusCode int
+}
+
+// newConditionalCases - Precondition combinations and edge cases for an object with the given ETag and Last-Modified.
+func newConditionalCases(etag string, lastModified time.Time) []conditionalCase {
+	past := lastModified.Add(-time.Hour).UTC().Format(http.TimeFormat)
+	future := time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)
+	bogusETag := "\"1234567890\""
+	return []conditionalCase{
+		// If-Match takes precedence over If-Unmodified-Since.
+		{"If-Match true, If-Unmodified-Since false", http.Header{
+			"If-Match":            []string{etag},
+			"If-Unmodified-Since": []string{past},
+		}, http.StatusOK},
+		{"If-Match false, If-Unmodified-Since true", http.Header{
+			"If-Match":            []string{bogusETag},
+			"If-Unmodified-Since": []string{future},
+		}, http.StatusPreconditionFailed},
+		// If-None-Match takes precedence over If-Modified-Since.
+		{"If-None-Match false, If-Modified-Since true", http.Header{
+			"If-None-Match":     []string{etag},
+			"If-Modified-Since": []string{past},
+		}, http.StatusNotModified},
+		// A list matches if any of its ETags match.
+		{"If-Match list", http.Header{
+			"If-Match": []string{bogusETag + ", " + etag},
+		}, http.StatusOK},
+		{"If-None-Match list", http.Header{
+			"If-None-Match": []string{bogusETag + ", " + etag},
+		}, http.StatusNotModified},
+		// If-Match uses the strong comparison, If-None-Match the weak comparison.
+		{"If-Match weak ETag", http.Header{
+			"If-Match": []string{"W/" + etag},
+		}, http.StatusPreconditionFailed},
+		{"If-None-Match weak ETag", http.Header{
+			"If-None-Match": []string{"W/" + etag},
+		}, http.StatusNotModified},
+		// * matches any existing object.
+		{"If-Match *", http.Header{
+			"If-Match": []string{"*"},
+		}, http.StatusOK},
+		{"If-None-Match *", http.Header{
+			"If-None-Match": []string{"*"},
+		}, http.StatusNotModified},
+		// Dates that can not be parsed are ignored.
+		{"If-Modified-Since malformed", http.Header{
+			"If-Modified-Since": []string{"not a date"},
+		}, http.StatusOK},
+		{"If-Unmodified-Since malformed", http.Header{
+			"If-Unmodified-Since": []string{"not a date"},
+		}, http.StatusOK},
+		// A date later than the current time is invalid for If-Modified-Since and ignored.
+		{"If-Modified-Since future", http.Header{
+			"If-Modified-Since": []string{future},
+		}, http.StatusOK},
+		{"If-Unmodified-Since future", http.Header{
+			"If-Unmodified-Since": []string{future},
+		}, http.StatusOK},
+	}
+}
+
+// copySourceConditionalHeader - The x-amz-copy-source-if-* equivalent of GET precondition headers.
+func copySourceConditionalHeader(header http.Header) http.Header {
+	copyHeader := http.Header{}
+	for k, v := range header {
+		copyHeader.Set("X-Amz-Copy-Source-"+k, v[0])
+	}
+	return copyHeader
+}
+
+// conditionalGet - GET or HEAD an object with precondition headers and verify the status returned.
+func conditionalGet(config ServerConfig, method, bucketName string, object *ObjectInfo, header http.Header, expectedStatusCode int) error {
+	var req Request
+	var err error
+	if method == "HEAD" {
+		req, err = newHeadObjectReq(bucketName, object.Key)
+	} else {
+		req, err = newGetObjectReq(bucketName, object.Key, nil)
+	}
+	if err != nil {
+		return err
+	}
+	for k, v := range header {
+		req.customHeader.Set(k, v[0])
+	}
+	// Execute the request.
+	res, err := config.execRequest(method, req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	// HEAD and 304 responses have no body.
+	if method == "HEAD" || expectedStatusCode == http.StatusNotModified {
+		return nil
+	}
+	if expectedStatusCode == http.StatusPreconditionFailed {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != "PreconditionFailed" {
+			err := fmt.Errorf("Unexpected Error Code: wanted PreconditionFailed, got %s", receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	return verifyBodyGetObject(res.Body, object.Body)
+}
+
+// conditionalCopy - Copy an object with x-amz-copy-source-if-* headers and verify the status returned.
+func conditionalCopy(config ServerConfig, sourceBucketName, sourceObjectName, destBucketName, destObjectName string, header http.Header, expectedStatusCode int) error {
+	if expectedStatusCode != http.StatusOK {
+		return copyObjectError(config, sourceBucketName, sourceObjectName, destBucketName, destObjectName, header, expectedStatusCode, ErrorResponse{Code: "PreconditionFailed"})
+	}
+	req, err := newCopyObjectHeadersReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName, header)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return copyObjectVerify(res, expectedStatusCode)
+}
+
+// mainConditionalPrecedence - Entry point for the test of combined and edge case precondition headers.
+func mainConditionalPrecedence(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Conditional Requests (Precedence):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	sourceBucketName := s3verifyBuckets[0].Name
+	destBucketName := s3verifyBuckets[1].Name
+	object := &ObjectInfo{
+		Key:  "s3verify/conditional/precedence",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	dest := &ObjectInfo{
+		Key: object.Key + "/copy",
+	}
+	// Save the objects so they are removed later.
+	conditionalObjects = append(conditionalObjects, object, dest)
+	if err := putObject(config, sourceBucketName, object); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Read the ETag and Last-Modified the preconditions are evaluated against.
+	req, err := newHeadObjectReq(sourceBucketName, object.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	lastModified, err := http.ParseTime(res.Header.Get("Last-Modified"))
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	for _, testCase := range newConditionalCases(res.Header.Get("ETag"), lastModified) {
+		// Spin scanBar
+		scanBar(message)
+		for _, method := range []string{"GET", "HEAD"} {
+			if err := conditionalGet(config, method, sourceBucketName, object, testCase.header, testCase.expectedStatusCode); err != nil {
+				err := fmt.Errorf("%s %s: %v", method, testCase.name, err)
+				printMessage(message, err)
+				return false
+			}
+		}
+		// CopyObject has no 304 response, it fails with 412 instead.
+		expectedStatusCode := testCase.expectedStatusCode
+		if expectedStatusCode == http.StatusNotModified {
+			expectedStatusCode = http.StatusPreconditionFailed
+		}
+		if err := conditionalCopy(config, sourceBucketName, object.Key, destBucketName, dest.Key, copySourceConditionalHeader(testCase.header), expectedStatusCode); err != nil {
+			err := fmt.Errorf("CopyObject %s: %v", testCase.name, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
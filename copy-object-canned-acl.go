@@ -0,0 +1,105 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// newCopyObjectCannedACLReq - Create a new HTTP request for a CopyObject with a canned ACL.
+func newCopyObjectCannedACLReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName, acl string) (Request, error) {
+	copyObjectCannedACLReq, err := newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName)
+	if err != nil {
+		return Request{}, err
+	}
+	copyObjectCannedACLReq.customHeader.Set(aclHeader, acl)
+
+	return copyObjectCannedACLReq, nil
+}
+
+// mainCopyObjectCannedACL - Entry point for the CopyObject test with a canned ACL.
+func mainCopyObjectCannedACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] CopyObject (Canned ACL):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The private object is uploaded by the PutObject (Canned ACL) test.
+	if aclPrivateObject.Key == "" {
+		err := fmt.Errorf("Private ACL object was not created")
+		printMessage(message, err)
+		return false
+	}
+	bucketName := s3verifyACLBucket.Name
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// The copy is given its own ACL instead of the one of its private source.
+	acl := cannedACLs[1] // public-read
+	destObject := &ObjectInfo{
+		Key:  "s3verify/acl/copy",
+		Body: aclPrivateObject.Body,
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Create a new request.
+	req, err := newCopyObjectCannedACLReq(bucketName, aclPrivateObject.Key, bucketName, destObject.Key, acl.name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	notImplemented, err := isACLNotImplemented(res)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if notImplemented {
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// Verify the response.
+	if err := copyObjectVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the copied object so it is removed later.
+	aclObjects = append(aclObjects, destObject)
+	// Spin scanBar
+	scanBar(message)
+	if err := verifyObjectACL(config, bucketName, destObject.Key, aclOwner, cannedACLGrants(aclOwner, acl)); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyAnonymousGetObject(config, bucketName, destObject, true); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
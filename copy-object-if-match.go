@@ -23,7 +23,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 )
 
 // newCopyObjectIfMatchReq - Create a new HTTP request for a PUT copy object.
@@ -45,7 +44,7 @@ func newCopyObjectIfMatchReq(sourceBucketName, sourceObjectName, destBucketName,
 	copyObjectIfMatchReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
 	// Content-MD5 should not be set for CopyObject request.
 	// Content-Length should not be set for CopyObject request.
-	copyObjectIfMatchReq.customHeader.Set("x-amz-copy-source", url.QueryEscape(sourceBucketName+"/"+sourceObjectName))
+	copyObjectIfMatchReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
 	copyObjectIfMatchReq.customHeader.Set("x-amz-copy-source-if-match", ETag)
 	copyObjectIfMatchReq.customHeader.Set("User-Agent", appUserAgent)
 
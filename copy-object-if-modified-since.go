@@ -23,7 +23,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -46,7 +45,7 @@ func newCopyObjectIfModifiedSinceReq(sourceBucketName, sourceObjectName, destBuc
 	}
 	// Set the header.
 	copyObjectIfModifiedSinceReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
-	copyObjectIfModifiedSinceReq.customHeader.Set("x-amz-copy-source", url.QueryEscape(sourceBucketName+"/"+sourceObjectName))
+	copyObjectIfModifiedSinceReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
 	copyObjectIfModifiedSinceReq.customHeader.Set("x-amz-copy-source-if-modified-since", lastModified.Format(http.TimeFormat))
 	copyObjectIfModifiedSinceReq.customHeader.Set("User-Agent", appUserAgent)
 
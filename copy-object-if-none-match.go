@@ -22,7 +22,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
 )
 
 // newPutObjectCopyIfNoneMatchReq - Create a new HTTP request for a CopyObject with the if-none-match header set.
@@ -43,7 +42,7 @@ func newCopyObjectIfNoneMatchReq(sourceBucketName, sourceObjectName, destBucketN
 	}
 	// Fill in the request header.
 	copyObjectIfNoneMatchReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
-	copyObjectIfNoneMatchReq.customHeader.Set("x-amz-copy-source", url.QueryEscape(sourceBucketName+"/"+sourceObjectName))
+	copyObjectIfNoneMatchReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
 	copyObjectIfNoneMatchReq.customHeader.Set("x-amz-copy-source-if-none-match", ETag)
 	copyObjectIfNoneMatchReq.customHeader.Set("User-Agent", appUserAgent)
 
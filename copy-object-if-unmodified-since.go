@@ -23,7 +23,6 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"time"
 )
 
@@ -45,7 +44,7 @@ func newCopyObjectIfUnModifiedSinceReq(sourceBucketName, sourceObjectName, destB
 		return Request{}, err
 	}
 	copyObjectIfUnModifiedSinceReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
-	copyObjectIfUnModifiedSinceReq.customHeader.Set("x-amz-copy-source", url.QueryEscape(sourceBucketName+"/"+sourceObjectName))
+	copyObjectIfUnModifiedSinceReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
 	copyObjectIfUnModifiedSinceReq.customHeader.Set("x-amz-copy-if-unmodified-since", lastModified.Format(http.TimeFormat))
 	copyObjectIfUnModifiedSinceReq.customHeader.Set("User-Agent", appUserAgent)
 
@@ -0,0 +1,147 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Largest source CopyObject accepts, anything larger must be copied with UploadPartCopy.
+const maxCopyObjectSize int64 = 5 * 1024 * 1024 * 1024
+
+// buildCopiedObject - Create an object out of numParts copies of the source, copied by the server with UploadPartCopy.
+// The upload is aborted when it can not be completed so no parts are left behind.
+func buildCopiedObject(config ServerConfig, bucketName, sourceObjectName, objectName string, numParts int) error {
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if err := copyParts(config, bucketName, sourceObjectName, objectName, uploadID, numParts); err != nil {
+		abortMultipartUpload(config, bucketName, objectName, uploadID)
+		return err
+	}
+	return nil
+}
+
+// copyParts - Copy numParts parts from the source into an upload and complete it.
+func copyParts(config ServerConfig, bucketName, sourceObjectName, objectName, uploadID string, numParts int) error {
+	partETags := []string{}
+	for i := 0; i < numParts; i++ {
+		req, err := newUploadPartCopyReq(bucketName, sourceObjectName, bucketName, objectName, uploadID, i+1, "")
+		if err != nil {
+			return err
+		}
+		partETag, err := uploadPartCopy(config, req, http.StatusOK, ErrorResponse{})
+		if err != nil {
+			return err
+		}
+		partETags = append(partETags, partETag)
+	}
+	return completeMultipartUploadParts(config, bucketName, objectName, uploadID, partETags)
+}
+
+// mainCopyObjectTooLarge - Entry point for the CopyObject test with a source larger than 5GB.
+func mainCopyObjectTooLarge(config ServerConfig, curTest int) bool {
+	// Over 5GB is stored until the run cleans up, so the test only runs when asked for,
+	// the test name reports when it is skipped.
+	testName := "CopyObject (Source Too Large)"
+	if !config.LargeObjects {
+		testName = "CopyObject (Source Too Large, Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	if !config.LargeObjects {
+		// Test skipped.
+		printMessage(message, nil)
+		return true
+	}
+	bucketName := s3verifyBuckets[0].Name
+	// Only 5MB is uploaded, the server copies it into a 160MB object
+	// and that into an object just over 5GB.
+	base := &ObjectInfo{
+		Key:  "s3verify/copy/large/5mb",
+		Body: make([]byte, 5*1024*1024),
+	}
+	if _, err := io.ReadFull(crand.Reader, base.Body); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	medium := &ObjectInfo{Key: "s3verify/copy/large/160mb"}
+	large := &ObjectInfo{Key: "s3verify/copy/large/5gb"}
+	// The copy is only created if CopyObject wrongly accepts the source.
+	largeCopy := &ObjectInfo{Key: large.Key + "/copy"}
+	// Save the objects so they are removed later.
+	copyObjects = append(copyObjects, base, medium, large, largeCopy)
+	if err := putObject(config, bucketName, base); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := buildCopiedObject(config, bucketName, base.Key, medium.Key, 32); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	numParts := int(maxCopyObjectSize/int64(32*len(base.Body))) + 1
+	if err := buildCopiedObject(config, bucketName, medium.Key, large.Key, numParts); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// CopyObject must refuse the source.
+	if err := copyObjectError(config, bucketName, large.Key, bucketName, largeCopy.Key, nil, http.StatusBadRequest, ErrorResponse{Code: "InvalidRequest"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// While UploadPartCopy can still copy from it.
+	uploadID, err := initiateMultipartUpload(config, bucketName, largeCopy.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	copyRange := fmt.Sprintf("bytes=%d-%d", maxCopyObjectSize, maxCopyObjectSize+int64(len(base.Body))-1)
+	req, err := newUploadPartCopyReq(bucketName, large.Key, bucketName, largeCopy.Key, uploadID, 1, copyRange)
+	if err != nil {
+		abortMultipartUpload(config, bucketName, largeCopy.Key, uploadID)
+		printMessage(message, err)
+		return false
+	}
+	if _, err := uploadPartCopy(config, req, http.StatusOK, ErrorResponse{}); err != nil {
+		// Abort the upload so its part is not left behind.
+		abortMultipartUpload(config, bucketName, largeCopy.Key, uploadID)
+		printMessage(message, err)
+		return false
+	}
+	if err := abortMultipartUpload(config, bucketName, largeCopy.Key, uploadID); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,119 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Header deciding whether a copy keeps the metadata of its source or replaces it.
+const metadataDirectiveHeader = "X-Amz-Metadata-Directive"
+
+// newCopyObjectMetadataReq - Create a new HTTP request for CopyObject with a metadata directive.
+func newCopyObjectMetadataReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName, directive string, metadata http.Header) (Request, error) {
+	copyObjectMetadataReq, err := newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName)
+	if err != nil {
+		return Request{}, err
+	}
+	copyObjectMetadataReq.customHeader.Set(metadataDirectiveHeader, directive)
+	for k, v := range metadata {
+		copyObjectMetadataReq.customHeader.Set(k, v[0])
+	}
+	return copyObjectMetadataReq, nil
+}
+
+// copyObjectMetadata - Copy an object with a metadata directive and verify the metadata of the copy.
+func copyObjectMetadata(config ServerConfig, bucketName string, source, dest *ObjectInfo, directive string, metadata http.Header) error {
+	req, err := newCopyObjectMetadataReq(bucketName, source.Key, bucketName, dest.Key, directive, metadata)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := copyObjectVerify(res, http.StatusOK); err != nil {
+		return err
+	}
+	if err := headObjectMetadata(config, bucketName, dest); err != nil {
+		return fmt.Errorf("HEAD %s: %v", dest.Key, err)
+	}
+	if err := getObjectMetadata(config, bucketName, dest); err != nil {
+		return fmt.Errorf("GET %s: %v", dest.Key, err)
+	}
+	return nil
+}
+
+// mainCopyObjectMetadata - Entry point for the CopyObject test with COPY and REPLACE metadata directives.
+func mainCopyObjectMetadata(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] CopyObject (Metadata Directive):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Copy the object holding both user metadata and system headers.
+	if len(metadataObjects) < 2 {
+		err := fmt.Errorf("Metadata objects were not created")
+		printMessage(message, err)
+		return false
+	}
+	source := metadataObjects[1]
+	// Metadata sent along with the COPY directive is ignored.
+	copyDest := &ObjectInfo{
+		Key:      "s3verify/metadata-copy/copy",
+		Body:     source.Body,
+		Metadata: source.Metadata,
+	}
+	// The REPLACE directive keeps none of the metadata of the source.
+	replaceDest := &ObjectInfo{
+		Key:  "s3verify/metadata-copy/replace",
+		Body: source.Body,
+		Metadata: http.Header{
+			"X-Amz-Meta-Replaced": []string{"true"},
+			"Cache-Control":       []string{"no-cache"},
+			"Content-Type":        []string{"application/json"},
+		},
+	}
+	// Save the objects so they are removed later.
+	metadataObjects = append(metadataObjects, copyDest, replaceDest)
+	// Spin scanBar
+	scanBar(message)
+	ignored := http.Header{
+		"X-Amz-Meta-Ignored": []string{"true"},
+		"Cache-Control":      []string{"no-store"},
+	}
+	if err := copyObjectMetadata(config, bucketName, source, copyDest, "COPY", ignored); err != nil {
+		err := fmt.Errorf("COPY: %v", err)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := copyObjectMetadata(config, bucketName, source, replaceDest, "REPLACE", replaceDest.Metadata); err != nil {
+		err := fmt.Errorf("REPLACE: %v", err)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
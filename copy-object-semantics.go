@@ -0,0 +1,289 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// newCopyObjectHeadersReq - Create a new HTTP request for CopyObject with additional headers.
+func newCopyObjectHeadersReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName string, header http.Header) (Request, error) {
+	copyObjectReq, err := newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName)
+	if err != nil {
+		return Request{}, err
+	}
+	for k, v := range header {
+		copyObjectReq.customHeader.Set(k, v[0])
+	}
+	return copyObjectReq, nil
+}
+
+// verifyCopyObjectResult - Verify that the ETag and LastModified of a copy match a HEAD on the destination.
+func verifyCopyObjectResult(header http.Header, result copyObjectResult) error {
+	if etag := strings.Trim(header.Get("ETag"), "\""); etag != strings.Trim(result.ETag, "\"") {
+		err := fmt.Errorf("Unexpected ETag Received: CopyObject returned %v, HEAD returned %v", result.ETag, etag)
+		return err
+	}
+	lastModified, err := time.Parse(time.RFC3339Nano, result.LastModified)
+	if err != nil {
+		return err
+	}
+	headLastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	if err != nil {
+		return err
+	}
+	// The Last-Modified header only has a precision of seconds.
+	if !lastModified.Truncate(time.Second).Equal(headLastModified) {
+		err := fmt.Errorf("Unexpected Last-Modified Received: CopyObject returned %v, HEAD returned %v", result.LastModified, header.Get("Last-Modified"))
+		return err
+	}
+	return nil
+}
+
+// copyObjectSemantics - Copy an object with additional headers and verify the copy against the source.
+func copyObjectSemantics(config ServerConfig, sourceBucketName string, source *ObjectInfo, destBucketName string, dest *ObjectInfo, header http.Header) error {
+	req, err := newCopyObjectHeadersReq(sourceBucketName, source.Key, destBucketName, dest.Key, header)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyStatusCopyObject(res.StatusCode, http.StatusOK); err != nil {
+		return err
+	}
+	result := copyObjectResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return err
+	}
+	// The copy must hold the data of the source.
+	if err := verifyObjectBody(config, destBucketName, dest); err != nil {
+		return err
+	}
+	headReq, err := newHeadObjectReq(destBucketName, dest.Key)
+	if err != nil {
+		return err
+	}
+	headRes, err := config.execRequest("HEAD", headReq)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(headRes)
+	if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+		return err
+	}
+	if err := verifyCopyObjectResult(headRes.Header, result); err != nil {
+		return err
+	}
+	if storageClass := header.Get(storageClassHeader); storageClass != "" && headRes.Header.Get(storageClassHeader) != storageClass {
+		err := fmt.Errorf("Unexpected %s Received: wanted %v, got %v", storageClassHeader, storageClass, headRes.Header.Get(storageClassHeader))
+		return err
+	}
+	if dest.Metadata != nil {
+		return verifyObjectMetadata(headRes.Header, dest.Metadata)
+	}
+	return nil
+}
+
+// copyObjectError - Copy an object with additional headers that is expected to fail.
+func copyObjectError(config ServerConfig, sourceBucketName, sourceObjectName, destBucketName, destObjectName string, header http.Header, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newCopyObjectHeadersReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName, header)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the request failed as expected.
+	if err := verifyStatusCopyObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// putMultipartObject - Upload an object as a multipart upload of the given parts.
+// The upload is aborted when it can not be completed so no parts are left behind.
+func putMultipartObject(config ServerConfig, bucketName, objectName string, parts [][]byte) error {
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	if err := uploadPartsAndComplete(config, bucketName, objectName, uploadID, parts); err != nil {
+		abortMultipartUpload(config, bucketName, objectName, uploadID)
+		return err
+	}
+	return nil
+}
+
+// uploadPartsAndComplete - Upload the given parts into an upload and complete it.
+func uploadPartsAndComplete(config ServerConfig, bucketName, objectName, uploadID string, parts [][]byte) error {
+	partETags := []string{}
+	for i, part := range parts {
+		partETag, err := uploadPart(config, bucketName, objectName, uploadID, i+1, part)
+		if err != nil {
+			return err
+		}
+		partETags = append(partETags, partETag)
+	}
+	return completeMultipartUploadParts(config, bucketName, objectName, uploadID, partETags)
+}
+
+// mainCopyObjectSemantics - Entry point for the CopyObject test across buckets, onto itself and from special sources.
+func mainCopyObjectSemantics(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] CopyObject (Semantics):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	sourceBucketName := s3verifyBuckets[0].Name
+	destBucketName := s3verifyBuckets[1].Name
+	source := &ObjectInfo{
+		Key:  "s3verify/copy/semantics/source",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	// A key holding characters that must be encoded in x-amz-copy-source.
+	encodedSource := &ObjectInfo{
+		Key:  "s3verify/copy/semantics/a key+with?reserved&chars=%#",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	// Save the objects so they are removed later.
+	copyObjects = append(copyObjects, source, encodedSource)
+	for _, object := range []*ObjectInfo{source, encodedSource} {
+		if err := putObject(config, sourceBucketName, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Every part but the last must be at least 5MB.
+	multipartSource := &ObjectInfo{
+		Key: "s3verify/copy/semantics/multipart",
+	}
+	parts := [][]byte{make([]byte, 5*1024*1024), make([]byte, 1024)}
+	for _, part := range parts {
+		if _, err := io.ReadFull(crand.Reader, part); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		multipartSource.Body = append(multipartSource.Body, part...)
+	}
+	copyObjects = append(copyObjects, multipartSource)
+	if err := putMultipartObject(config, sourceBucketName, multipartSource.Key, parts); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Copies that must succeed.
+	successCases := []struct {
+		name   string
+		source *ObjectInfo
+		// Bucket and object the source is copied to.
+		destBucketName string
+		dest           *ObjectInfo
+		header         http.Header
+	}{
+		{"cross-bucket", source, destBucketName, &ObjectInfo{Key: source.Key, Body: source.Body}, nil},
+		{"encoded source", encodedSource, destBucketName, &ObjectInfo{Key: encodedSource.Key, Body: encodedSource.Body}, nil},
+		{"multipart source", multipartSource, destBucketName, &ObjectInfo{Key: multipartSource.Key, Body: multipartSource.Body}, nil},
+		// Copying an object onto itself is allowed once its metadata is replaced.
+		{"copy-to-self REPLACE", source, sourceBucketName, &ObjectInfo{
+			Key:  source.Key,
+			Body: source.Body,
+			Metadata: http.Header{
+				"X-Amz-Meta-Replaced": []string{"true"},
+			},
+		}, http.Header{
+			metadataDirectiveHeader: []string{"REPLACE"},
+			"X-Amz-Meta-Replaced":   []string{"true"},
+		}},
+		// Or once its storage class is changed.
+		{"copy-to-self storage class", source, sourceBucketName, &ObjectInfo{Key: source.Key, Body: source.Body}, http.Header{
+			storageClassHeader: []string{"REDUCED_REDUNDANCY"},
+		}},
+		// Storage class can also change across buckets.
+		{"cross-bucket storage class", encodedSource, destBucketName, &ObjectInfo{Key: encodedSource.Key + "/reduced", Body: encodedSource.Body}, http.Header{
+			storageClassHeader: []string{"REDUCED_REDUNDANCY"},
+		}},
+	}
+	for _, testCase := range successCases {
+		// Spin scanBar
+		scanBar(message)
+		copyObjects = append(copyObjects, testCase.dest)
+		if err := copyObjectSemantics(config, sourceBucketName, testCase.source, testCase.destBucketName, testCase.dest, testCase.header); err != nil {
+			err := fmt.Errorf("%s: %v", testCase.name, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Copies that must fail.
+	errorCases := []struct {
+		name               string
+		sourceObjectName   string
+		destBucketName     string
+		destObjectName     string
+		header             http.Header
+		expectedStatusCode int
+		expectedError      ErrorResponse
+	}{
+		// Copying an object onto itself without changing anything is rejected.
+		{"copy-to-self", source.Key, sourceBucketName, source.Key, nil, http.StatusBadRequest, ErrorResponse{Code: "InvalidRequest"}},
+		{"copy-to-self COPY", source.Key, sourceBucketName, source.Key, http.Header{
+			metadataDirectiveHeader: []string{"COPY"},
+		}, http.StatusBadRequest, ErrorResponse{Code: "InvalidRequest"}},
+		// The source must exist.
+		{"missing source", "s3verify/copy/semantics/missing", destBucketName, "s3verify/copy/semantics/missing", nil, http.StatusNotFound, ErrorResponse{Code: "NoSuchKey"}},
+		// Only COPY and REPLACE are valid directives.
+		{"unknown directive", source.Key, destBucketName, source.Key + "/unknown", http.Header{
+			metadataDirectiveHeader: []string{"MERGE"},
+		}, http.StatusBadRequest, ErrorResponse{Code: "InvalidArgument"}},
+	}
+	for _, testCase := range errorCases {
+		// Spin scanBar
+		scanBar(message)
+		if err := copyObjectError(config, sourceBucketName, testCase.sourceObjectName, testCase.destBucketName, testCase.destObjectName, testCase.header, testCase.expectedStatusCode, testCase.expectedError); err != nil {
+			err := fmt.Errorf("%s: %v", testCase.name, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,188 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// newCopyObjectSSECReq - Create a new HTTP request for a CopyObject between customer key encrypted objects.
+func newCopyObjectSSECReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName string, sourceKey, destKey []byte) (Request, error) {
+	// An SSE-C copy is a regular CopyObject with the SSE-C headers set.
+	copyObjectSSECReq, err := newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName)
+	if err != nil {
+		return Request{}, err
+	}
+	// A nil sourceKey sends the request without any copy source SSE-C headers.
+	if sourceKey != nil {
+		setCopySourceSSECustomerHeaders(copyObjectSSECReq.customHeader, sourceKey)
+	}
+	setSSECustomerHeaders(copyObjectSSECReq.customHeader, destKey)
+
+	return copyObjectSSECReq, nil
+}
+
+// copyObjectSSECVerify - Verify that the response returned matches what is expected.
+func copyObjectSSECVerify(res *http.Response, expectedStatusCode int, destKey []byte, expectedError ErrorResponse) error {
+	if err := verifyStatusCopyObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderCopyObjectSSEC(res.Header, destKey, expectedError); err != nil {
+		return err
+	}
+	if err := verifyBodyCopyObjectSSEC(res.Body, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderCopyObjectSSEC - Verify that the header returned matches what is expected.
+func verifyHeaderCopyObjectSSEC(header http.Header, destKey []byte, expectedError ErrorResponse) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if expectedError.Code == "" {
+		// The headers describe the encryption of the destination object.
+		if err := verifySSECustomerHeaders(header, destKey); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBodyCopyObjectSSEC - Verify that the body returned is a CopyObjectResult or the expected error.
+func verifyBodyCopyObjectSSEC(resBody io.Reader, expectedError ErrorResponse) error {
+	if expectedError.Code != "" {
+		return verifySSEErrorResponse(resBody, expectedError)
+	}
+	return verifyBodyCopyObject(resBody)
+}
+
+// mainCopyObjectSSEC - Entry point for the CopyObject test with customer provided keys.
+func mainCopyObjectSSEC(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] CopyObject (SSE-C):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// The copy is encrypted with a different key than its source.
+	destKey, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if !isSecureEndpoint(config.Endpoint) {
+		// Over plain HTTP the request must be refused before the source is looked at.
+		req, err := newCopyObjectSSECReq(bucketName, s3verifyObjects[0].Key, bucketName, "s3verify/sse-c/copy", nil, destKey)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the request failed as expected.
+		if err := copyObjectSSECVerify(res, http.StatusBadRequest, destKey, errSSECustomerInsecure); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// Create a key the source was not encrypted with.
+	wrongKey, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// The source was uploaded by the PutObject (SSE-C) test.
+	if len(sseCustomerObjects) == 0 {
+		err := fmt.Errorf("SSE-C objects were not created")
+		printMessage(message, err)
+		return false
+	}
+	sourceObject := sseCustomerObjects[0]
+	destObject := &ObjectInfo{
+		Key:  "s3verify/sse-c/copy",
+		Body: sourceObject.Body,
+	}
+	// Test copying with no source key, the wrong source key and finally the correct source key.
+	testCases := []struct {
+		sourceKey          []byte
+		expectedStatusCode int
+		expectedError      ErrorResponse
+	}{
+		{nil, http.StatusBadRequest, ErrorResponse{Code: "InvalidRequest"}},
+		{wrongKey, http.StatusForbidden, ErrorResponse{Code: "AccessDenied"}},
+		{s3verifySSECustomerKey, http.StatusOK, ErrorResponse{}},
+	}
+	for _, testCase := range testCases {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newCopyObjectSSECReq(bucketName, sourceObject.Key, bucketName, destObject.Key, testCase.sourceKey, destKey)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := copyObjectSSECVerify(res, testCase.expectedStatusCode, destKey, testCase.expectedError); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Save the copied object so it is removed later.
+	copyObjects = append(copyObjects, destObject)
+	// Spin scanBar
+	scanBar(message)
+	// The copy must be readable with the destination key only.
+	getReq, err := newGetObjectSSECReq(bucketName, destObject.Key, destKey)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Verify the copy holds the same data as its source.
+	if err := getObjectSSECVerify(getRes, http.StatusOK, destObject.Body, destKey, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,127 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// newCopyObjectSSEReq - Create a new HTTP request for a CopyObject encrypting the copy with SSE-S3 or SSE-KMS.
+func newCopyObjectSSEReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName, algorithm, kmsKeyID string) (Request, error) {
+	// An SSE copy is a regular CopyObject with the SSE headers set for the destination.
+	copyObjectSSEReq, err := newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSEHeaders(copyObjectSSEReq.customHeader, algorithm, kmsKeyID)
+
+	return copyObjectSSEReq, nil
+}
+
+// copyObjectSSEVerify - Verify that the response returned matches what is expected.
+func copyObjectSSEVerify(res *http.Response, expectedStatusCode int, destObject *ObjectInfo) error {
+	if err := verifyStatusCopyObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderCopyObjectSSE(res.Header, destObject); err != nil {
+		return err
+	}
+	if err := verifyBodyCopyObject(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderCopyObjectSSE - Verify that the header returned reports how the copy was encrypted.
+func verifyHeaderCopyObjectSSE(header http.Header, destObject *ObjectInfo) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if err := verifySSEHeaders(header, destObject.ServerSideEncryption, destObject.KMSKeyID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mainCopyObjectSSE - Entry point for the CopyObject test with SSE-S3 and SSE-KMS.
+func mainCopyObjectSSE(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] CopyObject (SSE-S3/SSE-KMS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Copy the SSE-S3 object to SSE-KMS and the SSE-KMS object to SSE-S3.
+	sourceObjects := sseObjects[:2]
+	for i, sourceObject := range sourceObjects {
+		// Spin scanBar
+		scanBar(message)
+		reencryptAs := sourceObjects[len(sourceObjects)-1-i]
+		destObject := &ObjectInfo{
+			Key:                  sourceObject.Key + "-copy",
+			Body:                 sourceObject.Body,
+			ServerSideEncryption: reencryptAs.ServerSideEncryption,
+			KMSKeyID:             reencryptAs.KMSKeyID,
+		}
+		// Create a new request.
+		req, err := newCopyObjectSSEReq(bucketName, sourceObject.Key, bucketName, destObject.Key, destObject.ServerSideEncryption, destObject.KMSKeyID)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := copyObjectSSEVerify(res, http.StatusOK, destObject); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Save the copied object so it is removed later.
+		copyObjects = append(copyObjects, destObject)
+		// Spin scanBar
+		scanBar(message)
+		// The copy must hold the source data under its new encryption.
+		getReq, err := newGetObjectReq(bucketName, destObject.Key, nil)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		getRes, err := config.execRequest("GET", getReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(getRes)
+		// Verify the response.
+		if err := getObjectSSEVerify(getRes, http.StatusOK, destObject); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
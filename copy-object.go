@@ -23,9 +23,16 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+
+	"github.com/minio/s3verify/signv4"
 )
 
+// copySource - Encode the source of a copy for the x-amz-copy-source header.
+// Every reserved character is percent encoded so keys with spaces or '+' copy from the right object.
+func copySource(sourceBucketName, sourceObjectName string) string {
+	return signv4.EncodePath(sourceBucketName + "/" + sourceObjectName)
+}
+
 // newCopyObjectReq - Create a new HTTP request for PUT object with copy-
 func newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destObjectName string) (Request, error) {
 	var copyObjectReq = Request{
@@ -45,7 +52,7 @@ func newCopyObjectReq(sourceBucketName, sourceObjectName, destBucketName, destOb
 	// Fill request headers.
 	// Content-MD5 should never be set for CopyObject API.
 	copyObjectReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
-	copyObjectReq.customHeader.Set("x-amz-copy-source", url.QueryEscape(sourceBucketName+"/"+sourceObjectName))
+	copyObjectReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
 	copyObjectReq.customHeader.Set("User-Agent", appUserAgent)
 
 	return copyObjectReq, nil
@@ -16,7 +16,10 @@
 
 package main
 
-import "time"
+import (
+	"net/http"
+	"time"
+)
 
 // BucketInfo container for bucket metadata.
 type BucketInfo struct {
@@ -47,11 +50,20 @@ type ObjectInfo struct {
 	// The class of storage used to store the object.
 	StorageClass string `json:"storageClass"`
 
+	// Server side encryption requested for the object and the KMS key used with aws:kms.
+	ServerSideEncryption string `json:"serverSideEncryption"`
+	KMSKeyID             string `json:"kmsKeyId"`
+
 	// Error
 	Err error `json:"-"`
 
-	Body     []byte // Data held by the object.
-	UploadID string // To be set only for multipart uploaded objects.
+	Body      []byte  // Data held by the object.
+	UploadID  string  // To be set only for multipart uploaded objects.
+	VersionID string  // To be set only for objects in versioned buckets.
+	PartSizes []int64 // To be set only for completed multipart objects, the size of each part in order.
+
+	// User metadata and system headers sent with the object.
+	Metadata http.Header `xml:"-"`
 }
 
 // ObjectInfos - A container for ObjectInfo structs to allow sorting.
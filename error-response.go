@@ -44,7 +44,8 @@ type ErrorResponse struct {
 	HostID     string `xml:"HostId"`
 
 	// Region where the bucket is located. This header is returned
-	// only in HEAD bucket and ListObjects response.
+	// only in HEAD bucket and ListObjects response, and in the body
+	// of errors for requests made for the wrong region.
 	Region string
 }
 
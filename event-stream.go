@@ -0,0 +1,170 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// Sizes of the fixed parts of an event stream message.
+const (
+	// Total length, headers length and the CRC of both.
+	eventStreamPreludeLen = 12
+	// CRC of the whole message.
+	eventStreamMessageCRCLen = 4
+	// Largest message s3verify accepts, S3 never sends messages anywhere near this size.
+	eventStreamMaxMessageLen = 16 * 1024 * 1024
+)
+
+// eventStreamMessage - A single decoded message of an event stream response.
+type eventStreamMessage struct {
+	// Only string headers are kept, the only type S3 sends.
+	headers map[string]string
+	payload []byte
+}
+
+// messageType - The value of the :message-type header, either event or error.
+func (m eventStreamMessage) messageType() string {
+	return m.headers[":message-type"]
+}
+
+// eventType - The value of the :event-type header, e.g. Records, Stats or End.
+func (m eventStreamMessage) eventType() string {
+	return m.headers[":event-type"]
+}
+
+// readEventStreamMessage - Read and validate the next message of an event stream.
+// io.EOF is returned once the stream ends between two messages.
+func readEventStreamMessage(r io.Reader) (eventStreamMessage, error) {
+	prelude := make([]byte, eventStreamPreludeLen)
+	if _, err := io.ReadFull(r, prelude); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = fmt.Errorf("Unexpected End of Event Stream: incomplete prelude")
+		}
+		return eventStreamMessage{}, err
+	}
+	totalLen := binary.BigEndian.Uint32(prelude[0:4])
+	headersLen := binary.BigEndian.Uint32(prelude[4:8])
+	if crc := crc32.ChecksumIEEE(prelude[0:8]); crc != binary.BigEndian.Uint32(prelude[8:12]) {
+		err := fmt.Errorf("Unexpected Prelude CRC Received: computed %08x, got %08x", crc, binary.BigEndian.Uint32(prelude[8:12]))
+		return eventStreamMessage{}, err
+	}
+	// The lengths come from the server so they are bounded before allocating, and compared
+	// without adding to them so they can not overflow.
+	if totalLen > eventStreamMaxMessageLen {
+		err := fmt.Errorf("Unexpected Message Length Received: %d bytes, more than %d bytes", totalLen, eventStreamMaxMessageLen)
+		return eventStreamMessage{}, err
+	}
+	if totalLen < eventStreamPreludeLen+eventStreamMessageCRCLen || headersLen > totalLen-eventStreamPreludeLen-eventStreamMessageCRCLen {
+		err := fmt.Errorf("Unexpected Message Length Received: %d bytes with %d bytes of headers", totalLen, headersLen)
+		return eventStreamMessage{}, err
+	}
+	message := make([]byte, totalLen)
+	copy(message, prelude)
+	if _, err := io.ReadFull(r, message[eventStreamPreludeLen:]); err != nil {
+		err := fmt.Errorf("Unexpected End of Event Stream: %v", err)
+		return eventStreamMessage{}, err
+	}
+	crcOffset := totalLen - eventStreamMessageCRCLen
+	if crc := crc32.ChecksumIEEE(message[:crcOffset]); crc != binary.BigEndian.Uint32(message[crcOffset:]) {
+		err := fmt.Errorf("Unexpected Message CRC Received: computed %08x, got %08x", crc, binary.BigEndian.Uint32(message[crcOffset:]))
+		return eventStreamMessage{}, err
+	}
+	headers, err := decodeEventStreamHeaders(message[eventStreamPreludeLen : eventStreamPreludeLen+headersLen])
+	if err != nil {
+		return eventStreamMessage{}, err
+	}
+	return eventStreamMessage{
+		headers: headers,
+		payload: message[eventStreamPreludeLen+headersLen : crcOffset],
+	}, nil
+}
+
+// decodeEventStreamHeaders - Decode the headers of an event stream message.
+func decodeEventStreamHeaders(data []byte) (map[string]string, error) {
+	headers := map[string]string{}
+	r := bytes.NewReader(data)
+	for r.Len() > 0 {
+		nameLen, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		name := make([]byte, nameLen)
+		if _, err := io.ReadFull(r, name); err != nil {
+			return nil, err
+		}
+		valueType, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		// Length of the value of each header value type, -1 for types prefixed by their length.
+		var valueLen int
+		switch valueType {
+		case 0, 1: // Boolean true and false.
+			valueLen = 0
+		case 2: // Byte.
+			valueLen = 1
+		case 3: // Short.
+			valueLen = 2
+		case 4: // Integer.
+			valueLen = 4
+		case 5, 8: // Long and timestamp.
+			valueLen = 8
+		case 6, 7: // Byte array and string.
+			valueLen = -1
+		case 9: // UUID.
+			valueLen = 16
+		default:
+			err := fmt.Errorf("Unexpected Header Value Type Received for %s: %d", string(name), valueType)
+			return nil, err
+		}
+		if valueLen == -1 {
+			var length uint16
+			if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+				return nil, err
+			}
+			valueLen = int(length)
+		}
+		value := make([]byte, valueLen)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, err
+		}
+		if valueType == 7 {
+			headers[string(name)] = string(value)
+		}
+	}
+	return headers, nil
+}
+
+// decodeEventStream - Read every message of an event stream.
+func decodeEventStream(r io.Reader) ([]eventStreamMessage, error) {
+	messages := []eventStreamMessage{}
+	for {
+		message, err := readEventStreamMessage(r)
+		if err == io.EOF {
+			return messages, nil
+		}
+		if err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+}
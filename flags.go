@@ -64,4 +64,38 @@ var globalFlags = []cli.Flag{
 		Name:  "id",
 		Usage: "Provide a unique suffix for test objects/buckets",
 	},
+	cli.IntFlag{
+		Name:  "seed",
+		Usage: "Seed randomized tests, reuse the seed of a failed run to reproduce it",
+	},
+	cli.StringFlag{
+		Name:  "kms-key-id",
+		Value: "s3verify-kms-key",
+		Usage: "KMS key ID used to test SSE-KMS",
+		// Allow env. variables to be used as well as flags.
+		EnvVar: "S3_KMS_KEY_ID",
+	},
+	cli.StringFlag{
+		Name:  "webhook-arn",
+		Usage: "ARN of the webhook target the server sends bucket notifications to, e.g. arn:minio:sqs::_:webhook",
+		// Allow env. variables to be used as well as flags.
+		EnvVar: "S3_WEBHOOK_ARN",
+	},
+	cli.StringFlag{
+		Name:  "webhook-address",
+		Value: ":9010",
+		Usage: "Local address s3verify listens on for bucket notifications sent to the webhook",
+		// Allow env. variables to be used as well as flags.
+		EnvVar: "S3_WEBHOOK_ADDRESS",
+	},
+	cli.StringFlag{
+		Name:  "website-url",
+		Usage: "URL of the website endpoint of the server, e.g. http://s3-website-us-east-1.amazonaws.com",
+		// Allow env. variables to be used as well as flags.
+		EnvVar: "S3_WEBSITE_URL",
+	},
+	cli.BoolFlag{
+		Name:  "large-objects",
+		Usage: "Enable the extended tests that create objects larger than 5GB",
+	},
 }
@@ -0,0 +1,135 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// newGetBucketACLReq - Create a new HTTP request for the GetBucketAcl API.
+func newGetBucketACLReq(bucketName string) (Request, error) {
+	// getBucketACLReq - a new HTTP request for the GetBucketAcl API.
+	var getBucketACLReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketACLReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+	getBucketACLReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketACLReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketACLReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketACLReq, nil
+}
+
+// getBucketACLVerify - Verify that the response returned matches what is expected.
+func getBucketACLVerify(res *http.Response, expectedStatusCode int, expectedOwner owner, expectedGrants []grant) error {
+	if err := verifyStatusGetBucketACL(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetBucketACL(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetBucketACL(res.Body, expectedOwner, expectedGrants); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetBucketACL - Verify that the status returned matches what is expected.
+func verifyStatusGetBucketACL(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetBucketACL - Verify that the header returned matches what is expected.
+func verifyHeaderGetBucketACL(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetBucketACL - Verify that the ACL returned matches what is expected.
+func verifyBodyGetBucketACL(resBody io.Reader, expectedOwner owner, expectedGrants []grant) error {
+	receivedPolicy := accessControlPolicy{}
+	if err := xmlDecoder(resBody, &receivedPolicy); err != nil {
+		return err
+	}
+	return verifyAccessControlPolicy(receivedPolicy, expectedOwner, expectedGrants)
+}
+
+// verifyBucketACL - Retrieve the ACL of a bucket and verify it holds the expected grants.
+func verifyBucketACL(config ServerConfig, bucketName string, expectedOwner owner, expectedGrants []grant) error {
+	req, err := newGetBucketACLReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return getBucketACLVerify(res, http.StatusOK, expectedOwner, expectedGrants)
+}
+
+// mainGetBucketACL - Entry point for the GetBucketAcl API test.
+func mainGetBucketACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketAcl:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Buckets created without an ACL are private to their owner.
+	if err := verifyBucketACL(config, s3verifyBuckets[0].Name, aclOwner, cannedACLGrants(aclOwner, cannedACLs[0])); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,148 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// newGetBucketCorsReq - Create a new HTTP request for the GetBucketCors API.
+func newGetBucketCorsReq(bucketName string) (Request, error) {
+	// getBucketCorsReq - a new HTTP request for the GetBucketCors API.
+	var getBucketCorsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketCorsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("cors", "")
+	getBucketCorsReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketCorsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketCorsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketCorsReq, nil
+}
+
+// getBucketCorsVerify - Verify that the response returned matches what is expected.
+func getBucketCorsVerify(res *http.Response, expectedStatusCode int, expectedCors corsConfiguration, expectedError ErrorResponse) error {
+	if err := verifyStatusGetBucketCors(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetBucketCors(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetBucketCors(res.Body, expectedCors, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetBucketCors - Verify that the status returned matches what is expected.
+func verifyStatusGetBucketCors(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetBucketCors - Verify that the header returned matches what is expected.
+func verifyHeaderGetBucketCors(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetBucketCors - Verify that the CORS configuration returned matches what is expected.
+func verifyBodyGetBucketCors(resBody io.Reader, expectedCors corsConfiguration, expectedError ErrorResponse) error {
+	if expectedError.Code != "" { // Error is expected.
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(resBody, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	receivedCors := corsConfiguration{}
+	if err := xmlDecoder(resBody, &receivedCors); err != nil {
+		return err
+	}
+	// Only compare the rules, the XMLName may or may not carry a namespace.
+	if !reflect.DeepEqual(receivedCors.CORSRules, expectedCors.CORSRules) {
+		err := fmt.Errorf("Unexpected CORS Rules Received: wanted %v, got %v", expectedCors.CORSRules, receivedCors.CORSRules)
+		return err
+	}
+	return nil
+}
+
+// mainGetBucketCors - Entry point for the GetBucketCors API test.
+func mainGetBucketCors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketCors:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// CORS was configured on the first s3verify created bucket by the PutBucketCors test.
+	bucketName := s3verifyBuckets[0].Name
+	// Create a new request.
+	req, err := newGetBucketCorsReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := getBucketCorsVerify(res, http.StatusOK, s3verifyCors, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,152 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// newGetBucketEncryptionReq - Create a new HTTP request for the GetBucketEncryption API.
+func newGetBucketEncryptionReq(bucketName string) (Request, error) {
+	// getBucketEncryptionReq - a new HTTP request for the GetBucketEncryption API.
+	var getBucketEncryptionReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketEncryptionReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+	getBucketEncryptionReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketEncryptionReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketEncryptionReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketEncryptionReq, nil
+}
+
+// getBucketEncryptionVerify - Verify that the response returned matches what is expected.
+func getBucketEncryptionVerify(res *http.Response, expectedStatusCode int, expectedEncryption serverSideEncryptionConfiguration, expectedError ErrorResponse) error {
+	if err := verifyStatusGetBucketEncryption(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetBucketEncryption(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetBucketEncryption(res.Body, expectedEncryption, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetBucketEncryption - Verify that the status returned matches what is expected.
+func verifyStatusGetBucketEncryption(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetBucketEncryption - Verify that the header returned matches what is expected.
+func verifyHeaderGetBucketEncryption(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetBucketEncryption - Verify that the default encryption configuration returned matches what is expected.
+func verifyBodyGetBucketEncryption(resBody io.Reader, expectedEncryption serverSideEncryptionConfiguration, expectedError ErrorResponse) error {
+	if expectedError.Code != "" { // Error is expected.
+		return verifySSEErrorResponse(resBody, expectedError)
+	}
+	receivedEncryption := serverSideEncryptionConfiguration{}
+	if err := xmlDecoder(resBody, &receivedEncryption); err != nil {
+		return err
+	}
+	if len(receivedEncryption.Rules) != len(expectedEncryption.Rules) {
+		err := fmt.Errorf("Unexpected Number of Rules Received: wanted %d, got %d", len(expectedEncryption.Rules), len(receivedEncryption.Rules))
+		return err
+	}
+	for i, rule := range expectedEncryption.Rules {
+		expected := rule.ApplyServerSideEncryptionByDefault
+		received := receivedEncryption.Rules[i].ApplyServerSideEncryptionByDefault
+		if received.SSEAlgorithm != expected.SSEAlgorithm {
+			err := fmt.Errorf("Unexpected SSEAlgorithm Received: wanted %s, got %s", expected.SSEAlgorithm, received.SSEAlgorithm)
+			return err
+		}
+		// AWS may return the ARN of the key, other servers may return the key ID itself.
+		if !strings.HasSuffix(received.KMSMasterKeyID, expected.KMSMasterKeyID) {
+			err := fmt.Errorf("Unexpected KMSMasterKeyID Received: wanted %s, got %s", expected.KMSMasterKeyID, received.KMSMasterKeyID)
+			return err
+		}
+	}
+	return nil
+}
+
+// mainGetBucketEncryption - Entry point for the GetBucketEncryption API test.
+func mainGetBucketEncryption(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketEncryption:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Default encryption was configured on the second s3verify created bucket by the PutBucketEncryption test.
+	bucketName := s3verifyBuckets[1].Name
+	// Create a new request.
+	req, err := newGetBucketEncryptionReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := getBucketEncryptionVerify(res, http.StatusOK, newBucketEncryption(sseAlgorithmAES256, ""), ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,179 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newGetBucketLocationReq - Create a new HTTP request for the GetBucketLocation API.
+func newGetBucketLocationReq(bucketName string) (Request, error) {
+	// getBucketLocationReq - a new HTTP request for the GetBucketLocation API.
+	var getBucketLocationReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketLocationReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("location", "")
+	getBucketLocationReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketLocationReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketLocationReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketLocationReq, nil
+}
+
+// getBucketLocationVerify - Verify that the response returned matches what is expected.
+func getBucketLocationVerify(res *http.Response, expectedStatusCode int, expectedLocation string, expectedError ErrorResponse) error {
+	if err := verifyStatusGetBucketLocation(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetBucketLocation(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetBucketLocation(res.Body, expectedLocation, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetBucketLocation - Verify that the status returned matches what is expected.
+func verifyStatusGetBucketLocation(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetBucketLocation - Verify that the header returned matches what is expected.
+func verifyHeaderGetBucketLocation(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetBucketLocation - Verify that the location returned matches what is expected.
+func verifyBodyGetBucketLocation(resBody io.Reader, expectedLocation string, expectedError ErrorResponse) error {
+	if expectedError.Code != "" { // Error is expected.
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(resBody, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	receivedLocation := locationConstraint{}
+	if err := xmlDecoder(resBody, &receivedLocation); err != nil {
+		return err
+	}
+	if receivedLocation.Location != expectedLocation {
+		err := fmt.Errorf("Unexpected LocationConstraint Received: wanted %q, got %q", expectedLocation, receivedLocation.Location)
+		return err
+	}
+	return nil
+}
+
+// bucketLocation - The LocationConstraint reported for buckets in a region, us-east-1 is reported as empty.
+func bucketLocation(region string) string {
+	if region == globalDefaultRegion {
+		return ""
+	}
+	return region
+}
+
+// mainGetBucketLocation - Entry point for the GetBucketLocation API test.
+func mainGetBucketLocation(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketLocation:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Every s3verify created bucket was created in the configured region.
+	for _, bucket := range s3verifyBuckets {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newGetBucketLocationReq(bucket.Name)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("GET", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := getBucketLocationVerify(res, http.StatusOK, bucketLocation(config.Region), ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The location of a bucket that does not exist cannot be retrieved.
+	expectedError := ErrorResponse{
+		Code: "NoSuchBucket",
+	}
+	bucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "")
+	dneReq, err := newGetBucketLocationReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	dneRes, err := config.execRequest("GET", dneReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(dneRes)
+	// Verify the request failed as expected.
+	if err := getBucketLocationVerify(dneRes, http.StatusNotFound, "", expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
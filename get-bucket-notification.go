@@ -0,0 +1,143 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// newGetBucketNotificationReq - Create a new HTTP request for the GetBucketNotificationConfiguration API.
+func newGetBucketNotificationReq(bucketName string) (Request, error) {
+	// getBucketNotificationReq - a new HTTP request for the GetBucketNotificationConfiguration API.
+	var getBucketNotificationReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketNotificationReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("notification", "")
+	getBucketNotificationReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketNotificationReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketNotificationReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketNotificationReq, nil
+}
+
+// normalizeNotification - Sort events and filter rules and lowercase rule names,
+// servers are free to return them in any order and case.
+func normalizeNotification(notification notificationConfiguration) notificationConfiguration {
+	normalized := notificationConfiguration{}
+	for _, queue := range notification.QueueConfigurations {
+		normalizedQueue := queueConfiguration{
+			ID:     queue.ID,
+			Queue:  queue.Queue,
+			Events: append([]string{}, queue.Events...),
+		}
+		sort.Strings(normalizedQueue.Events)
+		// Only prefix and suffix rules exist, keep them in that order.
+		for _, name := range []string{"prefix", "suffix"} {
+			for _, rule := range queue.Filter.S3Key.FilterRules {
+				if strings.ToLower(rule.Name) == name {
+					normalizedQueue.Filter.S3Key.FilterRules = append(normalizedQueue.Filter.S3Key.FilterRules, filterRule{
+						Name:  name,
+						Value: rule.Value,
+					})
+				}
+			}
+		}
+		normalized.QueueConfigurations = append(normalized.QueueConfigurations, normalizedQueue)
+	}
+	return normalized
+}
+
+// getBucketNotification - Get the notification configuration of a bucket and verify it matches what is expected.
+func getBucketNotification(config ServerConfig, bucketName string, expectedNotification notificationConfiguration) error {
+	req, err := newGetBucketNotificationReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != http.StatusOK {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusOK, res.StatusCode)
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	notification := notificationConfiguration{}
+	if err := xmlDecoder(res.Body, &notification); err != nil {
+		return err
+	}
+	if received, expected := normalizeNotification(notification), normalizeNotification(expectedNotification); !reflect.DeepEqual(received, expected) {
+		err := fmt.Errorf("Unexpected Notification Configuration Received: wanted %+v, got %+v", expected, received)
+		return err
+	}
+	return nil
+}
+
+// mainGetBucketNotification - Entry point for the GetBucketNotificationConfiguration API test.
+func mainGetBucketNotification(config ServerConfig, curTest int) bool {
+	// Only servers with a webhook target are tested, the test name reports when the test is skipped.
+	testName := "GetBucketNotification"
+	if config.WebhookARN == "" {
+		testName = "GetBucketNotification (Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	if config.WebhookARN == "" {
+		// Test skipped.
+		printMessage(message, nil)
+		return true
+	}
+	// The configuration was set by the PutBucketNotification test.
+	bucketName := s3verifyBuckets[0].Name
+	if err := getBucketNotification(config, bucketName, newS3verifyNotification(config.WebhookARN)); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,106 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// newGetBucketOwnershipControlsReq - Create a new HTTP request for the GetBucketOwnershipControls API.
+func newGetBucketOwnershipControlsReq(bucketName string) (Request, error) {
+	// getBucketOwnershipControlsReq - a new HTTP request for the GetBucketOwnershipControls API.
+	var getBucketOwnershipControlsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketOwnershipControlsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+	getBucketOwnershipControlsReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketOwnershipControlsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketOwnershipControlsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketOwnershipControlsReq, nil
+}
+
+// getBucketOwnershipControls - Get the ownership controls of a bucket and verify they match what is expected.
+// A non empty expectedError code means no controls must be returned.
+func getBucketOwnershipControls(config ServerConfig, bucketName string, expectedControls ownershipControls, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newGetBucketOwnershipControlsReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		return nil
+	}
+	receivedControls := ownershipControls{}
+	if err := xmlDecoder(res.Body, &receivedControls); err != nil {
+		return err
+	}
+	// Only compare the rules, the XMLName may or may not carry a namespace.
+	receivedControls.XMLName = expectedControls.XMLName
+	if !reflect.DeepEqual(receivedControls, expectedControls) {
+		err := fmt.Errorf("Unexpected Ownership Controls Received: wanted %+v, got %+v", expectedControls, receivedControls)
+		return err
+	}
+	return nil
+}
+
+// mainGetBucketOwnershipControls - Entry point for the GetBucketOwnershipControls API test.
+func mainGetBucketOwnershipControls(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketOwnershipControls:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// BucketOwnerEnforced was the last setting applied by the PutBucketOwnershipControls test.
+	controls := newOwnershipControls(objectOwnershipBucketOwnerEnforced)
+	if err := getBucketOwnershipControls(config, s3verifyAccessBucket.Name, controls, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,118 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// newGetBucketWebsiteReq - Create a new HTTP request for the GetBucketWebsite API.
+func newGetBucketWebsiteReq(bucketName string) (Request, error) {
+	// getBucketWebsiteReq - a new HTTP request for the GetBucketWebsite API.
+	var getBucketWebsiteReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getBucketWebsiteReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("website", "")
+	getBucketWebsiteReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getBucketWebsiteReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getBucketWebsiteReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getBucketWebsiteReq, nil
+}
+
+// getBucketWebsite - Get the website configuration of a bucket and verify it matches what is expected.
+// A non empty expectedError code means no configuration must be returned.
+func getBucketWebsite(config ServerConfig, bucketName string, expectedWebsite websiteConfiguration, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newGetBucketWebsiteReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	receivedWebsite := websiteConfiguration{}
+	if err := xmlDecoder(res.Body, &receivedWebsite); err != nil {
+		return err
+	}
+	// Only compare the configuration, the XMLName may or may not carry a namespace.
+	receivedWebsite.XMLName = expectedWebsite.XMLName
+	if !reflect.DeepEqual(receivedWebsite, expectedWebsite) {
+		err := fmt.Errorf("Unexpected Website Configuration Received: wanted %+v, got %+v", expectedWebsite, receivedWebsite)
+		return err
+	}
+	return nil
+}
+
+// mainGetBucketWebsite - Entry point for the GetBucketWebsite API test.
+func mainGetBucketWebsite(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetBucketWebsite:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The website was configured on the first s3verify created bucket by the PutBucketWebsite test.
+	bucketName := s3verifyBuckets[0].Name
+	if err := getBucketWebsite(config, bucketName, s3verifyWebsite, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
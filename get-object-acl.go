@@ -0,0 +1,141 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// newGetObjectACLReq - Create a new HTTP request for the GetObjectAcl API.
+func newGetObjectACLReq(bucketName, objectName string) (Request, error) {
+	// getObjectACLReq - a new HTTP request for the GetObjectAcl API.
+	var getObjectACLReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	getObjectACLReq.bucketName = bucketName
+	getObjectACLReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+	getObjectACLReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getObjectACLReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getObjectACLReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getObjectACLReq, nil
+}
+
+// getObjectACLVerify - Verify that the response returned matches what is expected.
+func getObjectACLVerify(res *http.Response, expectedStatusCode int, expectedOwner owner, expectedGrants []grant) error {
+	if err := verifyStatusGetObjectACL(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectACL(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObjectACL(res.Body, expectedOwner, expectedGrants); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetObjectACL - Verify that the status returned matches what is expected.
+func verifyStatusGetObjectACL(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectACL - Verify that the header returned matches what is expected.
+func verifyHeaderGetObjectACL(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetObjectACL - Verify that the ACL returned matches what is expected.
+func verifyBodyGetObjectACL(resBody io.Reader, expectedOwner owner, expectedGrants []grant) error {
+	receivedPolicy := accessControlPolicy{}
+	if err := xmlDecoder(resBody, &receivedPolicy); err != nil {
+		return err
+	}
+	return verifyAccessControlPolicy(receivedPolicy, expectedOwner, expectedGrants)
+}
+
+// verifyObjectACL - Retrieve the ACL of an object and verify it holds the expected grants.
+func verifyObjectACL(config ServerConfig, bucketName, objectName string, expectedOwner owner, expectedGrants []grant) error {
+	req, err := newGetObjectACLReq(bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return getObjectACLVerify(res, http.StatusOK, expectedOwner, expectedGrants)
+}
+
+// mainGetObjectACL - Entry point for the GetObjectAcl API test.
+func mainGetObjectACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectAcl:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The private object is uploaded by the PutObject (Canned ACL) test.
+	if aclPrivateObject.Key == "" {
+		err := fmt.Errorf("Private ACL object was not created")
+		printMessage(message, err)
+		return false
+	}
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := verifyObjectACL(config, s3verifyACLBucket.Name, aclPrivateObject.Key, aclOwner, cannedACLGrants(aclOwner, cannedACLs[0])); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,121 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+)
+
+// Attributes compared between object versions, which are uploaded without an additional checksum.
+var versionedObjectAttributes = []string{objectAttributeETag, objectAttributeStorageClass, objectAttributeObjectSize}
+
+// setVersionID - Address a single version of an object.
+func setVersionID(req *Request, versionID string) {
+	if req.queryValues == nil {
+		req.queryValues = make(url.Values)
+	}
+	req.queryValues.Set("versionId", versionID)
+}
+
+// verifyObjectVersionAttributes - Verify that GetObjectAttributes on an object version matches HEAD on the same version.
+// An empty versionID addresses the latest version, which must be object.
+func verifyObjectVersionAttributes(config ServerConfig, object *ObjectInfo, versionID string) error {
+	bucketName := s3verifyObjectLockBucket.Name
+	headReq, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		return err
+	}
+	req, err := newGetObjectAttributesReq(bucketName, object.Key, versionedObjectAttributes)
+	if err != nil {
+		return err
+	}
+	if versionID != "" {
+		setVersionID(&headReq, versionID)
+		setVersionID(&req, versionID)
+	}
+	expected, _, err := headObjectAttributes(config, headReq)
+	if err != nil {
+		return err
+	}
+	// The version must be told apart by its data.
+	md5Sum := md5.Sum(object.Body)
+	if etag := hex.EncodeToString(md5Sum[:]); expected.ETag != etag {
+		err := fmt.Errorf("Unexpected ETag Received from HEAD: wanted %v, got %v", etag, expected.ETag)
+		return err
+	}
+	received, header, err := execGetObjectAttributes(config, req)
+	if err != nil {
+		return err
+	}
+	if err := verifyObjectAttributes(expected, received, versionedObjectAttributes, checksumAlgorithms[0]); err != nil {
+		return err
+	}
+	if receivedVersionID := header.Get(versionIDHeader); receivedVersionID != object.VersionID {
+		err := fmt.Errorf("Unexpected %s Received: wanted %v, got %v", versionIDHeader, object.VersionID, receivedVersionID)
+		return err
+	}
+	return nil
+}
+
+// mainGetObjectAttributesVersioned - Entry point for the GetObjectAttributes test on object versions.
+func mainGetObjectAttributesVersioned(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectAttributes (Versioned):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Object versions are only kept by the object lock enabled bucket.
+	if s3verifyObjectLockBucket.Name == "" {
+		err := fmt.Errorf("Object lock bucket was not created")
+		printMessage(message, err)
+		return false
+	}
+	objectName := "s3verify/attributes/versioned"
+	versions := []*ObjectInfo{}
+	for i := 0; i < 2; i++ {
+		// Spin scanBar
+		scanBar(message)
+		object, err := putObjectLockObject(config, objectName)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		versions = append(versions, object)
+	}
+	for _, object := range versions {
+		// Spin scanBar
+		scanBar(message)
+		if err := verifyObjectVersionAttributes(config, object, object.VersionID); err != nil {
+			err := fmt.Errorf("Version %s: %v", object.VersionID, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Without a version the latest version is described.
+	if err := verifyObjectVersionAttributes(config, versions[len(versions)-1], ""); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,443 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetObjectAttributes headers.
+const (
+	objectAttributesHeader = "X-Amz-Object-Attributes"
+	maxPartsHeader         = "X-Amz-Max-Parts"
+	partNumberMarkerHeader = "X-Amz-Part-Number-Marker"
+)
+
+// Object attributes that can be requested.
+const (
+	objectAttributeETag         = "ETag"
+	objectAttributeChecksum     = "Checksum"
+	objectAttributeObjectParts  = "ObjectParts"
+	objectAttributeStorageClass = "StorageClass"
+	objectAttributeObjectSize   = "ObjectSize"
+)
+
+// Parts are listed 1000 at a time unless fewer are requested.
+const defaultMaxParts = 1000
+
+var (
+	// Every attribute GetObjectAttributes returns.
+	objectAttributes = []string{objectAttributeETag, objectAttributeChecksum, objectAttributeObjectParts, objectAttributeStorageClass, objectAttributeObjectSize}
+
+	// Holds all objects uploaded by the GetObjectAttributes test.
+	attributesObjects = []*ObjectInfo{}
+)
+
+// newGetObjectAttributesReq - Create a new HTTP request for the GetObjectAttributes API.
+func newGetObjectAttributesReq(bucketName, objectName string, attributes []string) (Request, error) {
+	// getObjectAttributesReq - an HTTP request for GetObjectAttributes.
+	var getObjectAttributesReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	getObjectAttributesReq.bucketName = bucketName
+	getObjectAttributesReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("attributes", "")
+	getObjectAttributesReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because GET requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getObjectAttributesReq.customHeader.Set(objectAttributesHeader, strings.Join(attributes, ","))
+	getObjectAttributesReq.customHeader.Set("User-Agent", appUserAgent)
+	getObjectAttributesReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+
+	return getObjectAttributesReq, nil
+}
+
+// newGetObjectAttributesPartsReq - Create a new HTTP request for a single page of the parts of an object.
+func newGetObjectAttributesPartsReq(bucketName, objectName string, maxParts, partNumberMarker int) (Request, error) {
+	getObjectAttributesPartsReq, err := newGetObjectAttributesReq(bucketName, objectName, []string{objectAttributeObjectParts})
+	if err != nil {
+		return Request{}, err
+	}
+	getObjectAttributesPartsReq.customHeader.Set(maxPartsHeader, strconv.Itoa(maxParts))
+	if partNumberMarker > 0 {
+		getObjectAttributesPartsReq.customHeader.Set(partNumberMarkerHeader, strconv.Itoa(partNumberMarker))
+	}
+	return getObjectAttributesPartsReq, nil
+}
+
+// execGetObjectAttributes - Execute a GetObjectAttributes request and return the decoded result along with the response headers.
+func execGetObjectAttributes(config ServerConfig, req Request) (getObjectAttributesResult, http.Header, error) {
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return getObjectAttributesResult{}, nil, err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != http.StatusOK {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusOK, res.StatusCode)
+		return getObjectAttributesResult{}, nil, err
+	}
+	result := getObjectAttributesResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return getObjectAttributesResult{}, nil, err
+	}
+	return result, res.Header, nil
+}
+
+// getObjectAttributes - Request the given attributes of an object and return the decoded result.
+func getObjectAttributes(config ServerConfig, bucketName, objectName string, attributes []string) (getObjectAttributesResult, error) {
+	req, err := newGetObjectAttributesReq(bucketName, objectName, attributes)
+	if err != nil {
+		return getObjectAttributesResult{}, err
+	}
+	result, _, err := execGetObjectAttributes(config, req)
+	return result, err
+}
+
+// headObjectAttributes - Execute a HEAD request and return the attributes it reports along with the response headers.
+func headObjectAttributes(config ServerConfig, req Request) (getObjectAttributesResult, http.Header, error) {
+	// Execute the request.
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		return getObjectAttributesResult{}, nil, err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		return getObjectAttributesResult{}, nil, err
+	}
+	// HEAD leaves out the storage class of STANDARD objects.
+	storageClass := res.Header.Get(storageClassHeader)
+	if storageClass == "" {
+		storageClass = storageClassStandard
+	}
+	result := getObjectAttributesResult{
+		ETag:         strings.Trim(res.Header.Get("ETag"), "\""),
+		StorageClass: storageClass,
+		ObjectSize:   res.ContentLength,
+	}
+	return result, res.Header, nil
+}
+
+// isAttributeRequested - Whether an attribute is part of the attributes requested.
+func isAttributeRequested(attributes []string, attribute string) bool {
+	for _, requested := range attributes {
+		if requested == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// trimPartsCount - Composite checksums may or may not be followed by the number of parts.
+func trimPartsCount(checksum string) string {
+	return strings.SplitN(checksum, "-", 2)[0]
+}
+
+// verifyObjectAttributes - Verify that the attributes requested match what is expected and no others were returned.
+// The parts of an object are verified separately by verifyObjectAttributesParts.
+func verifyObjectAttributes(expected, received getObjectAttributesResult, attributes []string, a checksumAlgorithm) error {
+	if !isAttributeRequested(attributes, objectAttributeETag) {
+		expected.ETag = ""
+	}
+	if etag := strings.Trim(received.ETag, "\""); etag != expected.ETag {
+		err := fmt.Errorf("Unexpected ETag Received: wanted %v, got %v", expected.ETag, etag)
+		return err
+	}
+	if !isAttributeRequested(attributes, objectAttributeChecksum) {
+		if received.Checksum != (objectChecksums{}) {
+			err := fmt.Errorf("Unexpected Checksum Received: wanted none, got %+v", received.Checksum)
+			return err
+		}
+	} else if checksum := received.Checksum.get(a); trimPartsCount(checksum) != trimPartsCount(expected.Checksum.get(a)) {
+		err := fmt.Errorf("Unexpected Checksum%s Received: wanted %v, got %v", a.name, expected.Checksum.get(a), checksum)
+		return err
+	}
+	if !isAttributeRequested(attributes, objectAttributeObjectParts) && received.ObjectParts != nil {
+		err := fmt.Errorf("Unexpected ObjectParts Received: wanted none, got %+v", *received.ObjectParts)
+		return err
+	}
+	if !isAttributeRequested(attributes, objectAttributeStorageClass) {
+		if received.StorageClass != "" {
+			err := fmt.Errorf("Unexpected Storage Class Received: wanted none, got %s", received.StorageClass)
+			return err
+		}
+	} else if err := verifyStorageClass(expected.StorageClass, received.StorageClass); err != nil {
+		return err
+	}
+	if !isAttributeRequested(attributes, objectAttributeObjectSize) {
+		expected.ObjectSize = 0
+	}
+	if received.ObjectSize != expected.ObjectSize {
+		err := fmt.Errorf("Unexpected ObjectSize Received: wanted %d, got %d", expected.ObjectSize, received.ObjectSize)
+		return err
+	}
+	return nil
+}
+
+// verifyObjectAttributesParts - Verify a page of parts against the parts ListParts reported before the upload was completed.
+func verifyObjectAttributesParts(received *objectAttributesParts, listedParts []objectPart, a checksumAlgorithm, maxParts, partNumberMarker int) error {
+	if received == nil {
+		err := fmt.Errorf("Unexpected ObjectParts Received: wanted %d parts, got none", len(listedParts))
+		return err
+	}
+	if received.PartsCount != len(listedParts) {
+		err := fmt.Errorf("Unexpected PartsCount Received: wanted %d, got %d", len(listedParts), received.PartsCount)
+		return err
+	}
+	// The page holds the parts following the marker.
+	expectedParts := []objectPart{}
+	for _, part := range listedParts {
+		if part.PartNumber > partNumberMarker {
+			expectedParts = append(expectedParts, part)
+		}
+	}
+	isTruncated := len(expectedParts) > maxParts
+	if isTruncated {
+		expectedParts = expectedParts[:maxParts]
+	}
+	if len(received.Parts) != len(expectedParts) {
+		err := fmt.Errorf("Unexpected number of parts after marker %d: wanted %d, got %d", partNumberMarker, len(expectedParts), len(received.Parts))
+		return err
+	}
+	for i, part := range received.Parts {
+		expectedPart := expectedParts[i]
+		if part.PartNumber != expectedPart.PartNumber {
+			err := fmt.Errorf("Unexpected part number: wanted %d, got %d", expectedPart.PartNumber, part.PartNumber)
+			return err
+		}
+		if part.Size != expectedPart.Size {
+			err := fmt.Errorf("Unexpected Size for part %d: wanted %d, got %d", part.PartNumber, expectedPart.Size, part.Size)
+			return err
+		}
+		if checksum := part.get(a); checksum != expectedPart.get(a) {
+			err := fmt.Errorf("Unexpected Checksum%s for part %d: wanted %v, got %v", a.name, part.PartNumber, expectedPart.get(a), checksum)
+			return err
+		}
+	}
+	if received.IsTruncated != isTruncated {
+		err := fmt.Errorf("Unexpected IsTruncated after marker %d: wanted %v, got %v", partNumberMarker, isTruncated, received.IsTruncated)
+		return err
+	}
+	if isTruncated && received.NextPartNumberMarker != expectedParts[len(expectedParts)-1].PartNumber {
+		err := fmt.Errorf("Unexpected NextPartNumberMarker: wanted %d, got %d", expectedParts[len(expectedParts)-1].PartNumber, received.NextPartNumberMarker)
+		return err
+	}
+	return nil
+}
+
+// verifyObjectAttributesMatchHead - Request every attribute of an object, one at a time and then all together,
+// and verify they match what HEAD reports.
+func verifyObjectAttributesMatchHead(config ServerConfig, bucketName string, object *ObjectInfo, a checksumAlgorithm, checksum string) error {
+	headReq, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		return err
+	}
+	expected, headHeader, err := headObjectAttributes(config, headReq)
+	if err != nil {
+		return err
+	}
+	expected.Checksum.set(a, checksum)
+	requests := [][]string{}
+	for _, attribute := range objectAttributes {
+		requests = append(requests, []string{attribute})
+	}
+	requests = append(requests, objectAttributes)
+	for _, attributes := range requests {
+		req, err := newGetObjectAttributesReq(bucketName, object.Key, attributes)
+		if err != nil {
+			return err
+		}
+		received, header, err := execGetObjectAttributes(config, req)
+		if err != nil {
+			return fmt.Errorf("%s: %v", strings.Join(attributes, ","), err)
+		}
+		if err := verifyObjectAttributes(expected, received, attributes, a); err != nil {
+			return fmt.Errorf("%s: %v", strings.Join(attributes, ","), err)
+		}
+		if lastModified := header.Get("Last-Modified"); lastModified != headHeader.Get("Last-Modified") {
+			err := fmt.Errorf("Unexpected Last-Modified Received: HEAD returned %v, GetObjectAttributes returned %v", headHeader.Get("Last-Modified"), lastModified)
+			return err
+		}
+	}
+	return nil
+}
+
+// mainGetObjectAttributes - Entry point for the GetObjectAttributes API test.
+func mainGetObjectAttributes(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectAttributes:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Checksums are uploaded with every object so the parts of the multipart object are listed.
+	a := checksumAlgorithms[0]
+	single := &ObjectInfo{
+		Key:  "s3verify/attributes/single",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	singleChecksum := a.checksum(single.Body)
+	if err := putObjectChecksum(config, bucketName, single, a, singleChecksum, false, http.StatusOK, ""); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the object so it is removed later.
+	attributesObjects = append(attributesObjects, single)
+	// Spin scanBar
+	scanBar(message)
+	if err := verifyObjectAttributesMatchHead(config, bucketName, single, a, singleChecksum); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Every part but the last must be at least 5MB.
+	parts := [][]byte{make([]byte, 5*1024*1024), make([]byte, 5*1024*1024), make([]byte, 5*1024*1024), make([]byte, 1024)}
+	for _, part := range parts {
+		if _, err := io.ReadFull(crand.Reader, part); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	multipart := &ObjectInfo{
+		Key: "s3verify/attributes/multipart",
+	}
+	uploadID, err := initiateMultipartUploadChecksum(config, bucketName, multipart.Key, a)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before completing it.
+	completed := false
+	defer func() {
+		if !completed {
+			abortMultipartUpload(config, bucketName, multipart.Key, uploadID)
+		}
+	}()
+	complete := &completeMultipartUpload{}
+	for i, part := range parts {
+		// Spin scanBar
+		scanBar(message)
+		checksum := a.checksum(part)
+		etag, err := uploadPartChecksum(config, bucketName, multipart.Key, uploadID, i+1, part, a, checksum, false, http.StatusOK, "")
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		complPart := completePart{
+			PartNumber: i + 1,
+			ETag:       etag,
+		}
+		complPart.set(a, checksum)
+		complete.Parts = append(complete.Parts, complPart)
+		multipart.Body = append(multipart.Body, part...)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The parts reported by GetObjectAttributes must match the parts ListParts reported before completing.
+	listed, err := listPartsPage(config, bucketName, multipart.Key, uploadID, defaultMaxParts, 0)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	compositeChecksum := a.compositeChecksum(parts)
+	if err := completeMultipartUploadChecksum(config, bucketName, multipart.Key, uploadID, complete, a, compositeChecksum); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	completed = true
+	// Save the object so it is removed later.
+	attributesObjects = append(attributesObjects, multipart)
+	// Spin scanBar
+	scanBar(message)
+	if err := verifyObjectAttributesMatchHead(config, bucketName, multipart, a, compositeChecksum); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// The ETag reported is the ETag of a multipart object.
+	attributes, err := getObjectAttributes(config, bucketName, multipart.Key, objectAttributes)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if etag := strings.Trim(attributes.ETag, "\""); etag != multipartETag(parts) {
+		err := fmt.Errorf("Unexpected ETag Received: wanted %v, got %v", multipartETag(parts), etag)
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectAttributesParts(attributes.ObjectParts, listed.ObjectParts, a, defaultMaxParts, 0); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Walk the parts a page at a time.
+	maxParts := 3
+	for partNumberMarker, pages := 0, 0; ; pages++ {
+		// Spin scanBar
+		scanBar(message)
+		if pages > len(parts) {
+			err := fmt.Errorf("ObjectParts pagination did not terminate after %d pages", pages)
+			printMessage(message, err)
+			return false
+		}
+		req, err := newGetObjectAttributesPartsReq(bucketName, multipart.Key, maxParts, partNumberMarker)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		page, _, err := execGetObjectAttributes(config, req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifyObjectAttributesParts(page.ObjectParts, listed.ObjectParts, a, maxParts, partNumberMarker); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if !page.ObjectParts.IsTruncated {
+			break
+		}
+		partNumberMarker = page.ObjectParts.NextPartNumberMarker
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
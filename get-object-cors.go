@@ -0,0 +1,116 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// newGetObjectCorsReq - Create a new HTTP request for a GET object sent from a browser origin.
+func newGetObjectCorsReq(bucketName, objectName, origin string) (Request, error) {
+	// A CORS GET is a regular GET object with the Origin header set.
+	getObjectCorsReq, err := newGetObjectReq(bucketName, objectName, nil)
+	if err != nil {
+		return Request{}, err
+	}
+	getObjectCorsReq.customHeader.Set("Origin", origin)
+
+	return getObjectCorsReq, nil
+}
+
+// verifyHeaderGetObjectCors - Verify that the CORS headers returned match the origin of the request.
+func verifyHeaderGetObjectCors(header http.Header, expectedAllowOrigin string, expectedExposeHeaders []string) error {
+	if allowOrigin := header.Get("Access-Control-Allow-Origin"); allowOrigin != expectedAllowOrigin {
+		err := fmt.Errorf("Unexpected Access-Control-Allow-Origin Received: wanted %q, got %q", expectedAllowOrigin, allowOrigin)
+		return err
+	}
+	exposeHeaders := header.Get("Access-Control-Expose-Headers")
+	for _, exposeHeader := range expectedExposeHeaders {
+		if !headerListContains(exposeHeaders, exposeHeader) {
+			err := fmt.Errorf("Unexpected Access-Control-Expose-Headers Received: wanted %s to be exposed, got %s", exposeHeader, exposeHeaders)
+			return err
+		}
+	}
+	return nil
+}
+
+// mainGetObjectCors - Entry point for the GetObject test with an Origin header set.
+func mainGetObjectCors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObject (CORS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// CORS was configured on the first s3verify created bucket by the PutBucketCors test.
+	bucketName := s3verifyBuckets[0].Name
+	object := s3verifyObjects[0]
+	// The allowed origin must receive the CORS headers of its rule.
+	allowedRule := s3verifyCors.CORSRules[0]
+	allowedOrigin := allowedRule.AllowedOrigins[0]
+	req, err := newGetObjectCorsReq(bucketName, object.Key, allowedOrigin)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := getObjectVerify(res, object.Body, http.StatusOK, nil); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyHeaderGetObjectCors(res.Header, allowedOrigin, allowedRule.ExposeHeaders); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+
+	// An origin without a rule for GET must still be served but without any CORS headers.
+	disallowedReq, err := newGetObjectCorsReq(bucketName, object.Key, "http://www.example.com")
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	disallowedRes, err := config.execRequest("GET", disallowedReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(disallowedRes)
+	// Verify the response.
+	if err := getObjectVerify(disallowedRes, object.Body, http.StatusOK, nil); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyHeaderGetObjectCors(disallowedRes.Header, "", nil); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
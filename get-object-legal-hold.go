@@ -0,0 +1,135 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// newGetObjectLegalHoldReq - Create a new HTTP request for the GetObjectLegalHold API.
+func newGetObjectLegalHoldReq(bucketName, objectName, versionID string) (Request, error) {
+	// getObjectLegalHoldReq - a new HTTP request for the GetObjectLegalHold API.
+	var getObjectLegalHoldReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	getObjectLegalHoldReq.bucketName = bucketName
+	getObjectLegalHoldReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("legal-hold", "")
+	urlValues.Set("versionId", versionID)
+	getObjectLegalHoldReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getObjectLegalHoldReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getObjectLegalHoldReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getObjectLegalHoldReq, nil
+}
+
+// getObjectLegalHoldVerify - Verify that the response returned matches what is expected.
+func getObjectLegalHoldVerify(res *http.Response, expectedStatusCode int, expectedStatus string) error {
+	if err := verifyStatusGetObjectLegalHold(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectLegalHold(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObjectLegalHold(res.Body, expectedStatus); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetObjectLegalHold - Verify that the status returned matches what is expected.
+func verifyStatusGetObjectLegalHold(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectLegalHold - Verify that the header returned matches what is expected.
+func verifyHeaderGetObjectLegalHold(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetObjectLegalHold - Verify that the legal hold returned matches what is expected.
+func verifyBodyGetObjectLegalHold(resBody io.Reader, expectedStatus string) error {
+	receivedLegalHold := objectLegalHold{}
+	if err := xmlDecoder(resBody, &receivedLegalHold); err != nil {
+		return err
+	}
+	if receivedLegalHold.Status != expectedStatus {
+		err := fmt.Errorf("Unexpected Legal Hold Status Received: wanted %s, got %s", expectedStatus, receivedLegalHold.Status)
+		return err
+	}
+	return nil
+}
+
+// mainGetObjectLegalHold - Entry point for the GetObjectLegalHold API test.
+func mainGetObjectLegalHold(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectLegalHold:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The legal hold was placed by the PutObjectLegalHold test.
+	req, err := newGetObjectLegalHoldReq(s3verifyObjectLockBucket.Name, legalHoldObject.Key, legalHoldObject.VersionID)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := getObjectLegalHoldVerify(res, http.StatusOK, legalHoldOn); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,139 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+)
+
+// newGetObjectLockConfigurationReq - Create a new HTTP request for the GetObjectLockConfiguration API.
+func newGetObjectLockConfigurationReq(bucketName string) (Request, error) {
+	// getObjectLockConfigurationReq - a new HTTP request for the GetObjectLockConfiguration API.
+	var getObjectLockConfigurationReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getObjectLockConfigurationReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("object-lock", "")
+	getObjectLockConfigurationReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getObjectLockConfigurationReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getObjectLockConfigurationReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getObjectLockConfigurationReq, nil
+}
+
+// getObjectLockConfigurationVerify - Verify that the response returned matches what is expected.
+func getObjectLockConfigurationVerify(res *http.Response, expectedStatusCode int, expectedConfig objectLockConfiguration) error {
+	if err := verifyStatusGetObjectLockConfiguration(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectLockConfiguration(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObjectLockConfiguration(res.Body, expectedConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetObjectLockConfiguration - Verify that the status returned matches what is expected.
+func verifyStatusGetObjectLockConfiguration(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectLockConfiguration - Verify that the header returned matches what is expected.
+func verifyHeaderGetObjectLockConfiguration(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetObjectLockConfiguration - Verify that the object lock configuration returned matches what is expected.
+func verifyBodyGetObjectLockConfiguration(resBody io.Reader, expectedConfig objectLockConfiguration) error {
+	receivedConfig := objectLockConfiguration{}
+	if err := xmlDecoder(resBody, &receivedConfig); err != nil {
+		return err
+	}
+	if receivedConfig.ObjectLockEnabled != expectedConfig.ObjectLockEnabled {
+		err := fmt.Errorf("Unexpected ObjectLockEnabled Received: wanted %s, got %s", expectedConfig.ObjectLockEnabled, receivedConfig.ObjectLockEnabled)
+		return err
+	}
+	// Only compare the rules, the XMLName may or may not carry a namespace.
+	if !reflect.DeepEqual(receivedConfig.Rule, expectedConfig.Rule) {
+		err := fmt.Errorf("Unexpected Object Lock Rule Received: wanted %v, got %v", expectedConfig.Rule, receivedConfig.Rule)
+		return err
+	}
+	return nil
+}
+
+// mainGetObjectLockConfiguration - Entry point for the GetObjectLockConfiguration API test.
+func mainGetObjectLockConfiguration(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectLockConfiguration:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The default retention was configured by the PutObjectLockConfiguration test.
+	req, err := newGetObjectLockConfigurationReq(s3verifyObjectLockBucket.Name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := getObjectLockConfigurationVerify(res, http.StatusOK, s3verifyObjectLockConfig); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
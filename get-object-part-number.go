@@ -0,0 +1,155 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Header holding the number of parts of a multipart object.
+const partsCountHeader = "X-Amz-Mp-Parts-Count"
+
+// newGetObjectPartNumberReq - Create a new HTTP request for a single part of a multipart object.
+func newGetObjectPartNumberReq(bucketName, objectName string, partNumber int) (Request, error) {
+	getObjectPartNumberReq, err := newGetObjectReq(bucketName, objectName, nil)
+	if err != nil {
+		return Request{}, err
+	}
+	getObjectPartNumberReq.queryValues.Set("partNumber", strconv.Itoa(partNumber))
+	return getObjectPartNumberReq, nil
+}
+
+// partRange - The inclusive byte range a part covers within its object.
+func partRange(partSizes []int64, partNumber int) (startRange, endRange int64) {
+	for _, partSize := range partSizes[:partNumber-1] {
+		startRange += partSize
+	}
+	return startRange, startRange + partSizes[partNumber-1] - 1
+}
+
+// verifyHeaderPartNumber - Verify the parts count, Content-Range and Content-Length returned for a single part.
+func verifyHeaderPartNumber(header http.Header, object *ObjectInfo, partNumber int) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if partsCount := header.Get(partsCountHeader); partsCount != strconv.Itoa(len(object.PartSizes)) {
+		err := fmt.Errorf("Unexpected %s Received: wanted %d, got %q", partsCountHeader, len(object.PartSizes), partsCount)
+		return err
+	}
+	startRange, endRange := partRange(object.PartSizes, partNumber)
+	expectedContentRange := contentRangeHeader(startRange, endRange, int64(len(object.Body)))
+	if contentRange := header.Get("Content-Range"); contentRange != expectedContentRange {
+		err := fmt.Errorf("Unexpected Content-Range Received: wanted %q, got %q", expectedContentRange, contentRange)
+		return err
+	}
+	if contentLength := header.Get("Content-Length"); contentLength != strconv.FormatInt(endRange-startRange+1, 10) {
+		err := fmt.Errorf("Unexpected Content-Length Received: wanted %d, got %v", endRange-startRange+1, contentLength)
+		return err
+	}
+	return nil
+}
+
+// getObjectPartNumberVerify - Verify that a single part of an object was returned.
+func getObjectPartNumberVerify(res *http.Response, object *ObjectInfo, partNumber int) error {
+	if err := verifyStatusGetObject(res.StatusCode, http.StatusPartialContent); err != nil {
+		return err
+	}
+	if err := verifyHeaderPartNumber(res.Header, object, partNumber); err != nil {
+		return err
+	}
+	startRange, endRange := partRange(object.PartSizes, partNumber)
+	if err := verifyBodyGetObject(res.Body, object.Body[startRange:endRange+1]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyInvalidPartNumber - Verify that a part number past the last part fails with InvalidPartNumber.
+// HEAD responses carry no body so only the status is checked for them.
+func verifyInvalidPartNumber(res *http.Response, checkBody bool) error {
+	if err := verifyStatusGetObject(res.StatusCode, http.StatusRequestedRangeNotSatisfiable); err != nil {
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	if !checkBody {
+		return nil
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != "InvalidPartNumber" {
+		err := fmt.Errorf("Unexpected Error Code: wanted InvalidPartNumber, got %s", receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// getObjectPartNumber - GET every part of a multipart object followed by a part that does not exist.
+func getObjectPartNumber(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	for partNumber := 1; partNumber <= len(object.PartSizes)+1; partNumber++ {
+		req, err := newGetObjectPartNumberReq(bucketName, object.Key, partNumber)
+		if err != nil {
+			return err
+		}
+		// Execute the request.
+		res, err := config.execRequest("GET", req)
+		if err != nil {
+			return err
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if partNumber > len(object.PartSizes) {
+			err = verifyInvalidPartNumber(res, true)
+		} else {
+			err = getObjectPartNumberVerify(res, object, partNumber)
+		}
+		if err != nil {
+			return fmt.Errorf("%s part %d: %v", object.Key, partNumber, err)
+		}
+	}
+	return nil
+}
+
+// mainGetObjectPartNumber - Entry point for the GetObject test of single parts of multipart objects.
+func mainGetObjectPartNumber(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObject (Part Number):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for _, object := range multipartObjects {
+		// Only completed multipart objects have parts to fetch.
+		if len(object.PartSizes) == 0 {
+			continue
+		}
+		// Spin scanBar
+		scanBar(message)
+		if err := getObjectPartNumber(config, bucketName, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,210 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+)
+
+// Size of the object used to test range semantics.
+const rangeObjectSize = 1024
+
+// Holds all objects created by the range semantics test.
+var rangeObjects = []*ObjectInfo{}
+
+// rangeCase - A Range header along with the part of the object it should return.
+type rangeCase struct {
+	rangeHeader string
+	// expectedStatusCode is 206 for a satisfiable range, 200 when the range is ignored and 416 when it can not be satisfied.
+	expectedStatusCode int
+	startRange         int64
+	endRange           int64
+}
+
+// newRangeCases - Ranges covering every form of the Range header against an object of the given size.
+func newRangeCases(size int64) []rangeCase {
+	return []rangeCase{
+		// Suffix ranges return the last N bytes.
+		{"bytes=-100", http.StatusPartialContent, size - 100, size - 1},
+		{"bytes=-1", http.StatusPartialContent, size - 1, size - 1},
+		// A suffix longer than the object returns all of it.
+		{"bytes=-" + strconv.FormatInt(size*2, 10), http.StatusPartialContent, 0, size - 1},
+		// Open ended ranges return everything from the start.
+		{"bytes=100-", http.StatusPartialContent, 100, size - 1},
+		{"bytes=0-", http.StatusPartialContent, 0, size - 1},
+		// An end beyond the end of the object is clamped to the last byte.
+		{"bytes=100-" + strconv.FormatInt(size*4, 10), http.StatusPartialContent, 100, size - 1},
+		{"bytes=" + strconv.FormatInt(size-1, 10) + "-" + strconv.FormatInt(size, 10), http.StatusPartialContent, size - 1, size - 1},
+		// A range starting at or past the end of the object can not be satisfied.
+		{"bytes=" + strconv.FormatInt(size, 10) + "-", http.StatusRequestedRangeNotSatisfiable, 0, 0},
+		{"bytes=" + strconv.FormatInt(size+10, 10) + "-" + strconv.FormatInt(size+20, 10), http.StatusRequestedRangeNotSatisfiable, 0, 0},
+		// Multiple ranges are not supported so the whole object is returned.
+		{"bytes=0-9,20-29", http.StatusOK, 0, size - 1},
+	}
+}
+
+// getObjectInvalidRangeVerify - Verify that an unsatisfiable range fails with InvalidRange and the object size.
+func getObjectInvalidRangeVerify(res *http.Response, size int64) error {
+	if err := verifyStatusGetObject(res.StatusCode, http.StatusRequestedRangeNotSatisfiable); err != nil {
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	if contentRange := res.Header.Get("Content-Range"); contentRange != "bytes */"+strconv.FormatInt(size, 10) {
+		err := fmt.Errorf("Unexpected Content-Range Received: wanted %q, got %q", "bytes */"+strconv.FormatInt(size, 10), contentRange)
+		return err
+	}
+	// Ranges stay supported even when the one asked for can not be satisfied.
+	if acceptRanges := res.Header.Get("Accept-Ranges"); acceptRanges != "bytes" {
+		err := fmt.Errorf("Unexpected Accept-Ranges Received: wanted bytes, got %q", acceptRanges)
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != "InvalidRange" {
+		err := fmt.Errorf("Unexpected Error Code: wanted InvalidRange, got %s", receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// getObjectInvalidRange - GET an object with a Range header that can not be satisfied.
+func getObjectInvalidRange(config ServerConfig, bucketName string, object *ObjectInfo, rangeHeader string) error {
+	req, err := newGetObjectRangeHeaderReq(bucketName, object.Key, rangeHeader)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := getObjectInvalidRangeVerify(res, int64(len(object.Body))); err != nil {
+		err := fmt.Errorf("Range %q: %v", rangeHeader, err)
+		return err
+	}
+	return nil
+}
+
+// getEmptyObject - GET a zero byte object without a Range header and verify that ranges are still advertised.
+func getEmptyObject(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newGetObjectReq(bucketName, object.Key, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return getObjectRangeVerify(res, http.StatusOK, object.Body, "")
+}
+
+// verifyRangeCase - GET a single range case and verify the response.
+func verifyRangeCase(config ServerConfig, bucketName string, object *ObjectInfo, rc rangeCase) error {
+	size := int64(len(object.Body))
+	switch rc.expectedStatusCode {
+	case http.StatusRequestedRangeNotSatisfiable:
+		return getObjectInvalidRange(config, bucketName, object, rc.rangeHeader)
+	case http.StatusOK:
+		// The whole object is returned without a Content-Range.
+		return getObjectRange(config, bucketName, object.Key, rc.rangeHeader, http.StatusOK, object.Body, "")
+	default:
+		return getObjectRange(config, bucketName, object.Key, rc.rangeHeader, http.StatusPartialContent,
+			object.Body[rc.startRange:rc.endRange+1], contentRangeHeader(rc.startRange, rc.endRange, size))
+	}
+}
+
+// mainGetObjectRangeSemantics - Entry point for the GetObject range semantics test.
+func mainGetObjectRangeSemantics(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObject (Range Semantics):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Object data and ranges are drawn from the global seed so a failed run can be reproduced with --seed.
+	random := rand.New(rand.NewSource(globalSeed))
+	bucketName := s3verifyBuckets[0].Name
+	object := &ObjectInfo{
+		Key:  "s3verify/range/object",
+		Body: make([]byte, rangeObjectSize),
+	}
+	random.Read(object.Body)
+	emptyObject := &ObjectInfo{
+		Key:  "s3verify/range/empty",
+		Body: []byte{},
+	}
+	for _, rangeObject := range []*ObjectInfo{object, emptyObject} {
+		// Spin scanBar
+		scanBar(message)
+		// Save the object so it is removed later.
+		rangeObjects = append(rangeObjects, rangeObject)
+		if err := putObject(config, bucketName, rangeObject); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	rangeCases := newRangeCases(rangeObjectSize)
+	// Add random ranges that lie entirely within the object.
+	for i := 0; i < 10; i++ {
+		startRange := random.Int63n(rangeObjectSize)
+		endRange := random.Int63n(rangeObjectSize-startRange) + startRange
+		rangeCases = append(rangeCases, rangeCase{
+			rangeHeader:        "bytes=" + strconv.FormatInt(startRange, 10) + "-" + strconv.FormatInt(endRange, 10),
+			expectedStatusCode: http.StatusPartialContent,
+			startRange:         startRange,
+			endRange:           endRange,
+		})
+	}
+	for _, rc := range rangeCases {
+		// Spin scanBar
+		scanBar(message)
+		if err := verifyRangeCase(config, bucketName, object, rc); err != nil {
+			err := fmt.Errorf("%v (seed %d)", err, globalSeed)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// A zero byte object is returned whole and still advertises ranges.
+	if err := getEmptyObject(config, bucketName, emptyObject); err != nil {
+		err := fmt.Errorf("GET %s: %v", emptyObject.Key, err)
+		printMessage(message, err)
+		return false
+	}
+	// No range of a zero byte object can be satisfied.
+	for _, rangeHeader := range []string{"bytes=0-", "bytes=0-0"} {
+		if err := getObjectInvalidRange(config, bucketName, emptyObject, rangeHeader); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -23,11 +23,15 @@ import (
 	"math/rand"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 // newGetObjectRangeReq - Create a new GET object range request.
 func newGetObjectRangeReq(bucketName, objectName string, startRange, endRange int64) (Request, error) {
+	return newGetObjectRangeHeaderReq(bucketName, objectName, "bytes="+strconv.FormatInt(startRange, 10)+"-"+strconv.FormatInt(endRange, 10))
+}
+
+// newGetObjectRangeHeaderReq - Create a new GET object request with any Range header value.
+func newGetObjectRangeHeaderReq(bucketName, objectName, rangeHeader string) (Request, error) {
 	// getObjectRangeReq - a new HTTP request for a GET object with a specific range request.
 	var getObjectRangeReq = Request{
 		customHeader: http.Header{},
@@ -44,42 +48,93 @@ func newGetObjectRangeReq(bucketName, objectName string, startRange, endRange in
 	}
 
 	// Set the headers.
-	getObjectRangeReq.customHeader.Set("Range", "bytes="+strconv.FormatInt(startRange, 10)+"-"+strconv.FormatInt(endRange, 10))
+	getObjectRangeReq.customHeader.Set("Range", rangeHeader)
 	getObjectRangeReq.customHeader.Set("User-Agent", appUserAgent)
 	getObjectRangeReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
 	return getObjectRangeReq, nil
 }
 
+// contentRangeHeader - Format the Content-Range returned for an inclusive byte range of an object.
+func contentRangeHeader(startRange, endRange, size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", startRange, endRange, size)
+}
+
+// verifyHeaderGetObjectRange - Verify the Content-Range, Content-Length and Accept-Ranges of a range response.
+// An empty expectedContentRange means no Content-Range may be returned.
+func verifyHeaderGetObjectRange(header http.Header, expectedContentRange string, expectedContentLength int) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if contentRange := header.Get("Content-Range"); contentRange != expectedContentRange {
+		err := fmt.Errorf("Unexpected Content-Range Received: wanted %q, got %q", expectedContentRange, contentRange)
+		return err
+	}
+	if contentLength := header.Get("Content-Length"); contentLength != strconv.Itoa(expectedContentLength) {
+		err := fmt.Errorf("Unexpected Content-Length Received: wanted %d, got %v", expectedContentLength, contentLength)
+		return err
+	}
+	if acceptRanges := header.Get("Accept-Ranges"); acceptRanges != "bytes" {
+		err := fmt.Errorf("Unexpected Accept-Ranges Received: wanted bytes, got %q", acceptRanges)
+		return err
+	}
+	return nil
+}
+
+// getObjectRangeVerify - Verify the status, headers and body returned for a satisfiable range.
+func getObjectRangeVerify(res *http.Response, expectedStatusCode int, expectedBody []byte, expectedContentRange string) error {
+	if err := verifyStatusGetObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectRange(res.Header, expectedContentRange, len(expectedBody)); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObject(res.Body, expectedBody); err != nil {
+		return err
+	}
+	return nil
+}
+
+// getObjectRange - GET an object with a Range header and verify the part of the object returned.
+func getObjectRange(config ServerConfig, bucketName, objectName, rangeHeader string, expectedStatusCode int, expectedBody []byte, expectedContentRange string) error {
+	req, err := newGetObjectRangeHeaderReq(bucketName, objectName, rangeHeader)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := getObjectRangeVerify(res, expectedStatusCode, expectedBody, expectedContentRange); err != nil {
+		err := fmt.Errorf("Range %q: %v", rangeHeader, err)
+		return err
+	}
+	return nil
+}
+
 // Test a GET object request with a range header set.
 func mainGetObjectRange(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] GetObject (Range):", curTest, globalTotalNumTest)
 	// Spin scanBar
 	scanBar(message)
-	rand.Seed(time.Now().UnixNano())
+	// Ranges are drawn from the global seed so a failed run can be reproduced with --seed.
+	random := rand.New(rand.NewSource(globalSeed))
 	// All getobject tests happen in s3verify created buckets
 	// on s3verify created objects.
 	bucketName := s3verifyBuckets[0].Name
 	for _, object := range s3verifyObjects {
 		// Spin scanBar
 		scanBar(message)
-		startRange := rand.Int63n(object.Size)
-		endRange := rand.Int63n(int64(object.Size-startRange)) + startRange
-		// Create new GET object range request...testing range.
-		req, err := newGetObjectRangeReq(bucketName, object.Key, startRange, endRange)
-		if err != nil {
-			printMessage(message, err)
-			return false
-		}
-		// Execute the request.
-		res, err := config.execRequest("GET", req)
-		if err != nil {
-			printMessage(message, err)
-			return false
-		}
-		defer closeResponse(res)
+		size := int64(len(object.Body))
+		startRange := random.Int63n(size)
+		endRange := random.Int63n(size-startRange) + startRange
+		rangeHeader := "bytes=" + strconv.FormatInt(startRange, 10) + "-" + strconv.FormatInt(endRange, 10)
 		bufRange := object.Body[startRange : endRange+1]
-		// Verify the response...these checks do not check the headers yet.
-		if err := getObjectVerify(res, bufRange, http.StatusPartialContent, nil); err != nil {
+		// Verify the response.
+		if err := getObjectRange(config, bucketName, object.Key, rangeHeader, http.StatusPartialContent, bufRange, contentRangeHeader(startRange, endRange, size)); err != nil {
+			err := fmt.Errorf("%v (seed %d)", err, globalSeed)
 			printMessage(message, err)
 			return false
 		}
@@ -0,0 +1,142 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// newGetObjectRetentionReq - Create a new HTTP request for the GetObjectRetention API.
+func newGetObjectRetentionReq(bucketName, objectName, versionID string) (Request, error) {
+	// getObjectRetentionReq - a new HTTP request for the GetObjectRetention API.
+	var getObjectRetentionReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	getObjectRetentionReq.bucketName = bucketName
+	getObjectRetentionReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("retention", "")
+	urlValues.Set("versionId", versionID)
+	getObjectRetentionReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getObjectRetentionReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getObjectRetentionReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getObjectRetentionReq, nil
+}
+
+// getObjectRetentionVerify - Verify that the response returned matches what is expected.
+func getObjectRetentionVerify(res *http.Response, expectedStatusCode int, expectedRetention objectRetention) error {
+	if err := verifyStatusGetObjectRetention(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectRetention(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObjectRetention(res.Body, expectedRetention); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusGetObjectRetention - Verify that the status returned matches what is expected.
+func verifyStatusGetObjectRetention(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectRetention - Verify that the header returned matches what is expected.
+func verifyHeaderGetObjectRetention(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyGetObjectRetention - Verify that the retention returned matches what is expected.
+func verifyBodyGetObjectRetention(resBody io.Reader, expectedRetention objectRetention) error {
+	receivedRetention := objectRetention{}
+	if err := xmlDecoder(resBody, &receivedRetention); err != nil {
+		return err
+	}
+	if receivedRetention.Mode != expectedRetention.Mode {
+		err := fmt.Errorf("Unexpected Retention Mode Received: wanted %s, got %s", expectedRetention.Mode, receivedRetention.Mode)
+		return err
+	}
+	if !receivedRetention.RetainUntilDate.Equal(expectedRetention.RetainUntilDate) {
+		err := fmt.Errorf("Unexpected RetainUntilDate Received: wanted %s, got %s", expectedRetention.RetainUntilDate, receivedRetention.RetainUntilDate)
+		return err
+	}
+	return nil
+}
+
+// mainGetObjectRetention - Entry point for the GetObjectRetention API test.
+func mainGetObjectRetention(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObjectRetention:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The retentions were placed by the PutObjectRetention test.
+	for _, retained := range retainedObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newGetObjectRetentionReq(s3verifyObjectLockBucket.Name, retained.object.Key, retained.object.VersionID)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("GET", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := getObjectRetentionVerify(res, http.StatusOK, retained.retention); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,157 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// newGetObjectSSECReq - Create a new HTTP request for GET object encrypted with a customer provided key.
+func newGetObjectSSECReq(bucketName, objectName string, key []byte) (Request, error) {
+	// An SSE-C GET is a regular GET object with the SSE-C headers set.
+	getObjectSSECReq, err := newGetObjectReq(bucketName, objectName, nil)
+	if err != nil {
+		return Request{}, err
+	}
+	// A nil key sends the request without any SSE-C headers.
+	if key != nil {
+		setSSECustomerHeaders(getObjectSSECReq.customHeader, key)
+	}
+
+	return getObjectSSECReq, nil
+}
+
+// getObjectSSECVerify - Verify that the response returned matches what is expected.
+func getObjectSSECVerify(res *http.Response, expectedStatusCode int, expectedBody, key []byte, expectedError ErrorResponse) error {
+	if err := verifyStatusGetObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectSSEC(res.Header, key, expectedError); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObjectSSEC(res.Body, expectedBody, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectSSEC - Verify that the header returned matches what is expected.
+func verifyHeaderGetObjectSSEC(header http.Header, key []byte, expectedError ErrorResponse) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if expectedError.Code == "" {
+		if err := verifySSECustomerHeaders(header, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBodyGetObjectSSEC - Verify that the body returned is the decrypted object or the expected error.
+func verifyBodyGetObjectSSEC(resBody io.Reader, expectedBody []byte, expectedError ErrorResponse) error {
+	if expectedError.Code != "" {
+		return verifySSEErrorResponse(resBody, expectedError)
+	}
+	return verifyBodyGetObject(resBody, expectedBody)
+}
+
+// mainGetObjectSSEC - Entry point for the GetObject test with a customer provided key.
+func mainGetObjectSSEC(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObject (SSE-C):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Create a key the objects were not encrypted with.
+	wrongKey, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if !isSecureEndpoint(config.Endpoint) {
+		// Over plain HTTP the request must be refused before the object is looked at.
+		req, err := newGetObjectSSECReq(bucketName, s3verifyObjects[0].Key, wrongKey)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("GET", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the request failed as expected.
+		if err := getObjectSSECVerify(res, http.StatusBadRequest, nil, wrongKey, errSSECustomerInsecure); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// The objects were uploaded by the PutObject (SSE-C) test.
+	if len(sseCustomerObjects) == 0 {
+		err := fmt.Errorf("SSE-C objects were not created")
+		printMessage(message, err)
+		return false
+	}
+	for _, object := range sseCustomerObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Test GET with the correct key, no key and the wrong key.
+		testCases := []struct {
+			key                []byte
+			expectedStatusCode int
+			expectedError      ErrorResponse
+		}{
+			{s3verifySSECustomerKey, http.StatusOK, ErrorResponse{}},
+			{nil, http.StatusBadRequest, ErrorResponse{Code: "InvalidRequest"}},
+			{wrongKey, http.StatusForbidden, ErrorResponse{Code: "AccessDenied"}},
+		}
+		for _, testCase := range testCases {
+			// Create a new request.
+			req, err := newGetObjectSSECReq(bucketName, object.Key, testCase.key)
+			if err != nil {
+				printMessage(message, err)
+				return false
+			}
+			// Execute the request.
+			res, err := config.execRequest("GET", req)
+			if err != nil {
+				printMessage(message, err)
+				return false
+			}
+			defer closeResponse(res)
+			// Verify the response.
+			if err := getObjectSSECVerify(res, testCase.expectedStatusCode, object.Body, testCase.key, testCase.expectedError); err != nil {
+				printMessage(message, err)
+				return false
+			}
+			// Spin scanBar
+			scanBar(message)
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// getObjectSSEVerify - Verify that the response returned matches what is expected.
+func getObjectSSEVerify(res *http.Response, expectedStatusCode int, object *ObjectInfo) error {
+	if err := verifyStatusGetObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderGetObjectSSE(res.Header, object); err != nil {
+		return err
+	}
+	if err := verifyBodyGetObject(res.Body, object.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderGetObjectSSE - Verify that the header returned reports how the object was encrypted.
+func verifyHeaderGetObjectSSE(header http.Header, object *ObjectInfo) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if err := verifySSEHeaders(header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mainGetObjectSSE - Entry point for the GetObject test on SSE-S3 and SSE-KMS objects.
+func mainGetObjectSSE(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetObject (SSE-S3/SSE-KMS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for _, object := range sseObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Objects encrypted with SSE-S3 or SSE-KMS are read back without any special headers.
+		req, err := newGetObjectReq(bucketName, object.Key, nil)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("GET", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := getObjectSSEVerify(res, http.StatusOK, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,104 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newGetPublicAccessBlockReq - Create a new HTTP request for the GetPublicAccessBlock API.
+func newGetPublicAccessBlockReq(bucketName string) (Request, error) {
+	// getPublicAccessBlockReq - a new HTTP request for the GetPublicAccessBlock API.
+	var getPublicAccessBlockReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	getPublicAccessBlockReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+	getPublicAccessBlockReq.queryValues = urlValues
+
+	// No body is sent with GET requests.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	getPublicAccessBlockReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	getPublicAccessBlockReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return getPublicAccessBlockReq, nil
+}
+
+// getPublicAccessBlock - Get the public access block of a bucket and verify it matches what is expected.
+// A non empty expectedError code means no configuration must be returned.
+func getPublicAccessBlock(config ServerConfig, bucketName string, expectedPublicAccessBlock publicAccessBlockConfiguration, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newGetPublicAccessBlockReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		return nil
+	}
+	receivedPublicAccessBlock := publicAccessBlockConfiguration{}
+	if err := xmlDecoder(res.Body, &receivedPublicAccessBlock); err != nil {
+		return err
+	}
+	// Only compare the settings, the XMLName may or may not carry a namespace.
+	receivedPublicAccessBlock.XMLName = expectedPublicAccessBlock.XMLName
+	if receivedPublicAccessBlock != expectedPublicAccessBlock {
+		err := fmt.Errorf("Unexpected Public Access Block Received: wanted %+v, got %+v", expectedPublicAccessBlock, receivedPublicAccessBlock)
+		return err
+	}
+	return nil
+}
+
+// mainGetPublicAccessBlock - Entry point for the GetPublicAccessBlock API test.
+func mainGetPublicAccessBlock(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] GetPublicAccessBlock:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The public access block was set by the PutPublicAccessBlock test.
+	if err := getPublicAccessBlock(config, s3verifyAccessBucket.Name, s3verifyPublicAccessBlock, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -31,6 +31,7 @@ var (
 	globalTotalNumTest  int           // The total number of tests being run.
 	globalRandom        *rand.Rand    // A global random seed used by retry code.
 	globalSuffix        string        // The suffix to append to all s3verify created objects and buckets.
+	globalSeed          int64         // The seed used by tests with randomized inputs so a failing run can be reproduced.
 )
 
 // lockedRandSource provides protected rand source, implements rand.Source interface.
@@ -57,7 +58,7 @@ func (r *lockedRandSource) Seed(seed int64) {
 }
 
 // Separate out context.
-func setGlobals(verbose bool, numTests int, suffix string) {
+func setGlobals(verbose bool, numTests int, suffix string, seed int64) {
 	globalTotalNumTest = numTests
 	globalVerbose = verbose
 	if globalVerbose {
@@ -66,6 +67,7 @@ func setGlobals(verbose bool, numTests int, suffix string) {
 	}
 	globalRandom = rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())})
 	globalSuffix = suffix
+	globalSeed = seed
 }
 
 // Set any global flags here.
@@ -88,7 +90,12 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	if ctx.GlobalString("id") != "" {
 		suffix = ctx.GlobalString("id")
 	}
-	setGlobals(verbose, numTests, suffix)
+	// Use a new seed for every run unless one is provided to reproduce a previous run.
+	seed := int64(ctx.GlobalInt("seed"))
+	if seed == 0 {
+		seed = time.Now().UTC().UnixNano()
+	}
+	setGlobals(verbose, numTests, suffix, seed)
 
 	return nil
 }
@@ -0,0 +1,88 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// newHeadObjectPartNumberReq - Create a new HTTP HEAD request for a single part of a multipart object.
+func newHeadObjectPartNumberReq(bucketName, objectName string, partNumber int) (Request, error) {
+	headObjectPartNumberReq, err := newHeadObjectReq(bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("partNumber", strconv.Itoa(partNumber))
+	headObjectPartNumberReq.queryValues = urlValues
+	return headObjectPartNumberReq, nil
+}
+
+// headObjectPartNumber - HEAD every part of a multipart object followed by a part that does not exist.
+func headObjectPartNumber(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	for partNumber := 1; partNumber <= len(object.PartSizes)+1; partNumber++ {
+		req, err := newHeadObjectPartNumberReq(bucketName, object.Key, partNumber)
+		if err != nil {
+			return err
+		}
+		// Execute the request.
+		res, err := config.execRequest("HEAD", req)
+		if err != nil {
+			return err
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if partNumber > len(object.PartSizes) {
+			err = verifyInvalidPartNumber(res, false)
+		} else if err = verifyStatusHeadObject(res.StatusCode, http.StatusPartialContent); err == nil {
+			err = verifyHeaderPartNumber(res.Header, object, partNumber)
+		}
+		if err != nil {
+			return fmt.Errorf("%s part %d: %v", object.Key, partNumber, err)
+		}
+	}
+	return nil
+}
+
+// mainHeadObjectPartNumber - Entry point for the HeadObject test of single parts of multipart objects.
+func mainHeadObjectPartNumber(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] HeadObject (Part Number):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for _, object := range multipartObjects {
+		// Only completed multipart objects have parts to fetch.
+		if len(object.PartSizes) == 0 {
+			continue
+		}
+		// Spin scanBar
+		scanBar(message)
+		if err := headObjectPartNumber(config, bucketName, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
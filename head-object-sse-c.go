@@ -0,0 +1,155 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// newHeadObjectSSECReq - Create a new HTTP request for HEAD object encrypted with a customer provided key.
+func newHeadObjectSSECReq(bucketName, objectName string, key []byte) (Request, error) {
+	// An SSE-C HEAD is a regular HEAD object with the SSE-C headers set.
+	headObjectSSECReq, err := newHeadObjectReq(bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	// A nil key sends the request without any SSE-C headers.
+	if key != nil {
+		setSSECustomerHeaders(headObjectSSECReq.customHeader, key)
+	}
+
+	return headObjectSSECReq, nil
+}
+
+// headObjectSSECVerify - Verify that the response returned matches what is expected.
+func headObjectSSECVerify(res *http.Response, expectedStatusCode int, expectedSize int64, key []byte) error {
+	if err := verifyStatusHeadObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderHeadObjectSSEC(res.Header, expectedStatusCode, expectedSize, key); err != nil {
+		return err
+	}
+	if err := verifyBodyHeadObject(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderHeadObjectSSEC - Verify that the header returned matches what is expected.
+func verifyHeaderHeadObjectSSEC(header http.Header, expectedStatusCode int, expectedSize int64, key []byte) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	// HEAD errors do not carry a body so only successful responses can be checked further.
+	if expectedStatusCode != http.StatusOK {
+		return nil
+	}
+	if err := verifySSECustomerHeaders(header, key); err != nil {
+		return err
+	}
+	// The size reported must be that of the decrypted object.
+	if size := header.Get("Content-Length"); size != strconv.FormatInt(expectedSize, 10) {
+		err := fmt.Errorf("Unexpected Content-Length Received: wanted %d, got %s", expectedSize, size)
+		return err
+	}
+	return nil
+}
+
+// mainHeadObjectSSEC - Entry point for the HeadObject test with a customer provided key.
+func mainHeadObjectSSEC(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] HeadObject (SSE-C):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Create a key the objects were not encrypted with.
+	wrongKey, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if !isSecureEndpoint(config.Endpoint) {
+		// Over plain HTTP the request must be refused before the object is looked at.
+		req, err := newHeadObjectSSECReq(bucketName, s3verifyObjects[0].Key, wrongKey)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("HEAD", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the request failed as expected.
+		if err := headObjectSSECVerify(res, http.StatusBadRequest, 0, wrongKey); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// The objects were uploaded by the PutObject (SSE-C) test.
+	if len(sseCustomerObjects) == 0 {
+		err := fmt.Errorf("SSE-C objects were not created")
+		printMessage(message, err)
+		return false
+	}
+	for _, object := range sseCustomerObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Test HEAD with the correct key, no key and the wrong key.
+		testCases := []struct {
+			key                []byte
+			expectedStatusCode int
+		}{
+			{s3verifySSECustomerKey, http.StatusOK},
+			{nil, http.StatusBadRequest},
+			{wrongKey, http.StatusForbidden},
+		}
+		for _, testCase := range testCases {
+			// Create a new request.
+			req, err := newHeadObjectSSECReq(bucketName, object.Key, testCase.key)
+			if err != nil {
+				printMessage(message, err)
+				return false
+			}
+			// Execute the request.
+			res, err := config.execRequest("HEAD", req)
+			if err != nil {
+				printMessage(message, err)
+				return false
+			}
+			defer closeResponse(res)
+			// Verify the response.
+			if err := headObjectSSECVerify(res, testCase.expectedStatusCode, int64(len(object.Body)), testCase.key); err != nil {
+				printMessage(message, err)
+				return false
+			}
+			// Spin scanBar
+			scanBar(message)
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,84 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// headObjectSSEVerify - Verify that the response returned matches what is expected.
+func headObjectSSEVerify(res *http.Response, expectedStatusCode int, object *ObjectInfo) error {
+	if err := verifyStatusHeadObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderHeadObjectSSE(res.Header, object); err != nil {
+		return err
+	}
+	if err := verifyBodyHeadObject(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderHeadObjectSSE - Verify that the header returned reports how the object was encrypted.
+func verifyHeaderHeadObjectSSE(header http.Header, object *ObjectInfo) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if err := verifySSEHeaders(header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mainHeadObjectSSE - Entry point for the HeadObject test on SSE-S3 and SSE-KMS objects.
+func mainHeadObjectSSE(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] HeadObject (SSE-S3/SSE-KMS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for _, object := range sseObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Objects encrypted with SSE-S3 or SSE-KMS are read back without any special headers.
+		req, err := newHeadObjectReq(bucketName, object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("HEAD", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := headObjectSSEVerify(res, http.StatusOK, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,227 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// listMultipartUploadsPaginationMaxUploads - number of uploads requested per page.
+const listMultipartUploadsPaginationMaxUploads = 2
+
+// newListMultipartUploadsPageReq - Create a new HTTP request for a single page of the List Multipart Uploads API.
+func newListMultipartUploadsPageReq(bucketName, prefix, delimiter, keyMarker, uploadIDMarker string, maxUploads int) (Request, error) {
+	listMultipartUploadsPageReq, err := newListMultipartUploadsReq(bucketName)
+	if err != nil {
+		return Request{}, err
+	}
+	// Only set the parameters that are in use.
+	if prefix != "" {
+		listMultipartUploadsPageReq.queryValues.Set("prefix", prefix)
+	}
+	if delimiter != "" {
+		listMultipartUploadsPageReq.queryValues.Set("delimiter", delimiter)
+	}
+	if keyMarker != "" {
+		listMultipartUploadsPageReq.queryValues.Set("key-marker", keyMarker)
+	}
+	if uploadIDMarker != "" {
+		listMultipartUploadsPageReq.queryValues.Set("upload-id-marker", uploadIDMarker)
+	}
+	if maxUploads > 0 {
+		listMultipartUploadsPageReq.queryValues.Set("max-uploads", strconv.Itoa(maxUploads))
+	}
+	return listMultipartUploadsPageReq, nil
+}
+
+// listMultipartUploadsPage - Request a single page of multipart uploads and decode the result.
+func listMultipartUploadsPage(config ServerConfig, bucketName, prefix, delimiter, keyMarker, uploadIDMarker string, maxUploads int) (listMultipartUploadsResult, error) {
+	req, err := newListMultipartUploadsPageReq(bucketName, prefix, delimiter, keyMarker, uploadIDMarker, maxUploads)
+	if err != nil {
+		return listMultipartUploadsResult{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return listMultipartUploadsResult{}, err
+	}
+	defer closeResponse(res)
+	// Verify the status and headers before decoding.
+	if err := verifyStatusListMultipartUploads(res.StatusCode, http.StatusOK); err != nil {
+		return listMultipartUploadsResult{}, err
+	}
+	if err := verifyHeaderListMultipartUploads(res.Header); err != nil {
+		return listMultipartUploadsResult{}, err
+	}
+	result := listMultipartUploadsResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return listMultipartUploadsResult{}, err
+	}
+	return result, nil
+}
+
+// verifyListMultipartUploadsPage - verify a single page of uploads and record the uploads it listed.
+func verifyListMultipartUploadsPage(result listMultipartUploadsResult, expected map[string]string, listed map[string]bool, lastKey string) error {
+	if len(result.Uploads) == 0 || len(result.Uploads) > listMultipartUploadsPaginationMaxUploads {
+		err := fmt.Errorf("Unexpected number of uploads in page: wanted 1 to %d, got %d", listMultipartUploadsPaginationMaxUploads, len(result.Uploads))
+		return err
+	}
+	for _, upload := range result.Uploads {
+		key, ok := expected[upload.UploadID]
+		if !ok || key != upload.Key {
+			err := fmt.Errorf("Unexpected upload listed: %v (%v)", upload.Key, upload.UploadID)
+			return err
+		}
+		if listed[upload.UploadID] {
+			err := fmt.Errorf("Upload listed more than once: %v (%v)", upload.Key, upload.UploadID)
+			return err
+		}
+		// Uploads must be listed in key order across all pages.
+		if upload.Key < lastKey {
+			err := fmt.Errorf("Uploads not listed in key order: %v listed after %v", upload.Key, lastKey)
+			return err
+		}
+		lastKey = upload.Key
+		listed[upload.UploadID] = true
+	}
+	isTruncated := len(listed) < len(expected)
+	if result.IsTruncated != isTruncated {
+		err := fmt.Errorf("Unexpected IsTruncated after %d uploads: wanted %v, got %v", len(listed), isTruncated, result.IsTruncated)
+		return err
+	}
+	if isTruncated {
+		last := result.Uploads[len(result.Uploads)-1]
+		if result.NextKeyMarker != last.Key {
+			err := fmt.Errorf("Unexpected NextKeyMarker: wanted %v, got %v", last.Key, result.NextKeyMarker)
+			return err
+		}
+		if result.NextUploadIDMarker != last.UploadID {
+			err := fmt.Errorf("Unexpected NextUploadIdMarker: wanted %v, got %v", last.UploadID, result.NextUploadIDMarker)
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyListMultipartUploadsDelimiter - verify that a delimited listing groups nested uploads into common prefixes.
+func verifyListMultipartUploadsDelimiter(result listMultipartUploadsResult, expectedPrefixes []string, expectedKeys []string) error {
+	if len(result.CommonPrefixes) != len(expectedPrefixes) {
+		err := fmt.Errorf("Unexpected number of CommonPrefixes: wanted %d, got %d", len(expectedPrefixes), len(result.CommonPrefixes))
+		return err
+	}
+	for i, commonPrefix := range result.CommonPrefixes {
+		if commonPrefix.Prefix != expectedPrefixes[i] {
+			err := fmt.Errorf("Unexpected CommonPrefix: wanted %v, got %v", expectedPrefixes[i], commonPrefix.Prefix)
+			return err
+		}
+	}
+	if len(result.Uploads) != len(expectedKeys) {
+		err := fmt.Errorf("Unexpected number of uploads: wanted %d, got %d", len(expectedKeys), len(result.Uploads))
+		return err
+	}
+	for i, upload := range result.Uploads {
+		if upload.Key != expectedKeys[i] {
+			err := fmt.Errorf("Unexpected upload key: wanted %v, got %v", expectedKeys[i], upload.Key)
+			return err
+		}
+	}
+	return nil
+}
+
+// mainListMultipartUploadsPagination - Entry point for the List Multipart Uploads pagination test.
+func mainListMultipartUploadsPagination(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (List-Uploads Pagination):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	prefix := "s3verify/multipart/list-uploads/"
+	// Two of the uploads are nested under a delimiter and one key has two concurrent uploads.
+	objectNames := []string{
+		prefix + "dir0/key",
+		prefix + "dir1/key",
+		prefix + "key0",
+		prefix + "key0",
+		prefix + "key1",
+	}
+	// expected - maps each uploadID to the key it was initiated for.
+	expected := make(map[string]string)
+	// Abort the uploads if the test fails before aborting them itself.
+	defer func() {
+		for uploadID, objectName := range expected {
+			abortMultipartUpload(config, bucketName, objectName, uploadID)
+		}
+	}()
+	for _, objectName := range objectNames {
+		// Spin scanBar
+		scanBar(message)
+		uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		expected[uploadID] = objectName
+	}
+	// Walk every page following NextKeyMarker and NextUploadIdMarker.
+	listed := make(map[string]bool)
+	keyMarker, uploadIDMarker := "", ""
+	for {
+		// Spin scanBar
+		scanBar(message)
+		result, err := listMultipartUploadsPage(config, bucketName, prefix, "", keyMarker, uploadIDMarker, listMultipartUploadsPaginationMaxUploads)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifyListMultipartUploadsPage(result, expected, listed, keyMarker); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if !result.IsTruncated {
+			break
+		}
+		keyMarker, uploadIDMarker = result.NextKeyMarker, result.NextUploadIDMarker
+	}
+	// A delimited listing should roll the nested uploads up into common prefixes.
+	// Spin scanBar
+	scanBar(message)
+	result, err := listMultipartUploadsPage(config, bucketName, prefix, "/", "", "", 0)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	expectedPrefixes := []string{prefix + "dir0/", prefix + "dir1/"}
+	expectedKeys := []string{prefix + "key0", prefix + "key0", prefix + "key1"}
+	if err := verifyListMultipartUploadsDelimiter(result, expectedPrefixes, expectedKeys); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Remove all of the uploads created for this test.
+	for uploadID, objectName := range expected {
+		// Spin scanBar
+		scanBar(message)
+		if err := abortMultipartUpload(config, bucketName, objectName, uploadID); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		delete(expected, uploadID)
+	}
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,155 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Number of flat objects uploaded to the listing bucket, enough to exceed a single 1000 key page.
+const numListObjects = 1001
+
+// Largest number of keys a single listing may return regardless of max-keys.
+const maxListKeys = 1000
+
+var (
+	// The bucket holding the objects used by the listing pagination tests.
+	s3verifyListBucket BucketInfo
+
+	// All objects uploaded to the listing bucket, sorted by key.
+	listObjects = ObjectInfos{}
+
+	// Objects nested under several levels of delimiters.
+	nestedListObjectKeys = []string{
+		"nested/a/1",
+		"nested/a/b/2",
+		"nested/a/b/c/3",
+		"nested/d/4",
+		"nested/e",
+	}
+
+	// Objects whose keys must be encoded when encoding-type=url is requested.
+	encodedListObjectKeys = []string{
+		"encode/key with space",
+		"encode/key&ampersand",
+		"encode/ключ",
+	}
+)
+
+// verifyListBucketPrepared - Make sure the listing bucket was created and filled before listing it.
+func verifyListBucketPrepared() error {
+	if len(listObjects) < numListObjects {
+		return fmt.Errorf("Listing bucket was not prepared: wanted at least %d objects, found %d", numListObjects, len(listObjects))
+	}
+	return nil
+}
+
+// listEntry - A single key or common prefix in the order a listing returns it.
+type listEntry struct {
+	key      string
+	isPrefix bool
+	object   ObjectInfo
+}
+
+// newListObject - Create a listing object with random data and the ETag it will be listed with.
+func newListObject(objectName string) *ObjectInfo {
+	body := []byte(randString(60, rand.NewSource(time.Now().UnixNano()), ""))
+	md5Sum := md5.Sum(body)
+	return &ObjectInfo{
+		Key:  objectName,
+		Body: body,
+		Size: int64(len(body)),
+		ETag: hex.EncodeToString(md5Sum[:]),
+	}
+}
+
+// expectedListEntries - Compute every key and common prefix a listing should return after a marker.
+// The objects must already be sorted by key.
+func expectedListEntries(objects ObjectInfos, prefix, delimiter, marker string) []listEntry {
+	entries := []listEntry{}
+	for _, object := range objects {
+		if !strings.HasPrefix(object.Key, prefix) || object.Key <= marker {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(object.Key[len(prefix):], delimiter); i >= 0 {
+				commonPrefix := object.Key[:len(prefix)+i+len(delimiter)]
+				// A common prefix is only listed once and never again after it is used as a marker.
+				if commonPrefix <= marker {
+					continue
+				}
+				if len(entries) > 0 && entries[len(entries)-1].key == commonPrefix {
+					continue
+				}
+				entries = append(entries, listEntry{key: commonPrefix, isPrefix: true})
+				continue
+			}
+		}
+		entries = append(entries, listEntry{key: object.Key, object: object})
+	}
+	return entries
+}
+
+// verifyListEntries - Compare a single page of a listing key by key against the entries expected on it.
+func verifyListEntries(contents []ObjectInfo, commonPrefixes []commonPrefix, expected []listEntry) error {
+	expectedContents := []ObjectInfo{}
+	expectedPrefixes := []string{}
+	for _, entry := range expected {
+		if entry.isPrefix {
+			expectedPrefixes = append(expectedPrefixes, entry.key)
+		} else {
+			expectedContents = append(expectedContents, entry.object)
+		}
+	}
+	if len(contents) != len(expectedContents) || len(commonPrefixes) != len(expectedPrefixes) {
+		return fmt.Errorf("Unexpected Number of Objects Listed: wanted %d objects and %d prefixes, got %d objects and %d prefixes",
+			len(expectedContents), len(expectedPrefixes), len(contents), len(commonPrefixes))
+	}
+	for i, object := range contents {
+		if object.Key != expectedContents[i].Key {
+			return fmt.Errorf("Incorrect Key Received: wanted %s, got %s", expectedContents[i].Key, object.Key)
+		}
+		if strings.Trim(object.ETag, "\"") != expectedContents[i].ETag {
+			return fmt.Errorf("Incorrect ETag Received for %s: wanted %s, got %s", object.Key, expectedContents[i].ETag, object.ETag)
+		}
+		if object.Size != expectedContents[i].Size {
+			return fmt.Errorf("Incorrect Size Received for %s: wanted %d, got %d", object.Key, expectedContents[i].Size, object.Size)
+		}
+	}
+	for i, commonPrefix := range commonPrefixes {
+		if commonPrefix.Prefix != expectedPrefixes[i] {
+			return fmt.Errorf("Incorrect CommonPrefix Received: wanted %s, got %s", expectedPrefixes[i], commonPrefix.Prefix)
+		}
+	}
+	return nil
+}
+
+// listPageSize - The number of entries a page with max-keys set should hold when this many entries remain.
+func listPageSize(maxKeys, remaining int) int {
+	if maxKeys > maxListKeys {
+		maxKeys = maxListKeys
+	}
+	if remaining < maxKeys {
+		return remaining
+	}
+	return maxKeys
+}
@@ -0,0 +1,181 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// listObjectsV1Page - Request a single page of a ListObjects V1 listing and decode the result.
+func listObjectsV1Page(config ServerConfig, bucketName string, parameters map[string]string) (listBucketResult, error) {
+	req, err := newListObjectsV1Req(bucketName, parameters)
+	if err != nil {
+		return listBucketResult{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return listBucketResult{}, err
+	}
+	defer closeResponse(res)
+	// Verify the status and headers before decoding.
+	if err := verifyStatusListObjectsV1(res.StatusCode, http.StatusOK); err != nil {
+		return listBucketResult{}, err
+	}
+	if err := verifyHeaderListObjectsV1(res.Header); err != nil {
+		return listBucketResult{}, err
+	}
+	result := listBucketResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return listBucketResult{}, err
+	}
+	return result, nil
+}
+
+// listObjectsV1MaxKeys - Verify a single listing of the whole bucket with max-keys set.
+func listObjectsV1MaxKeys(config ServerConfig, bucketName string, maxKeys int) error {
+	result, err := listObjectsV1Page(config, bucketName, map[string]string{
+		"max-keys": strconv.Itoa(maxKeys),
+	})
+	if err != nil {
+		return err
+	}
+	expected := expectedListEntries(listObjects, "", "", "")
+	size := listPageSize(maxKeys, len(expected))
+	if err := verifyListEntries(result.Contents, result.CommonPrefixes, expected[:size]); err != nil {
+		return fmt.Errorf("max-keys=%d: %v", maxKeys, err)
+	}
+	// Whether an empty page is truncated differs between servers so it is not checked.
+	if maxKeys > 0 && result.IsTruncated != (size < len(expected)) {
+		return fmt.Errorf("max-keys=%d: Unexpected IsTruncated: wanted %v, got %v", maxKeys, size < len(expected), result.IsTruncated)
+	}
+	return nil
+}
+
+// listObjectsV1Walk - Walk every page of a listing following the marker and verify each page key by key.
+func listObjectsV1Walk(config ServerConfig, bucketName, prefix, delimiter string, maxKeys int) error {
+	expected := expectedListEntries(listObjects, prefix, delimiter, "")
+	listed := 0
+	marker := ""
+	for {
+		parameters := map[string]string{
+			"max-keys": strconv.Itoa(maxKeys),
+		}
+		if prefix != "" {
+			parameters["prefix"] = prefix
+		}
+		if delimiter != "" {
+			parameters["delimiter"] = delimiter
+		}
+		if marker != "" {
+			parameters["marker"] = marker
+		}
+		result, err := listObjectsV1Page(config, bucketName, parameters)
+		if err != nil {
+			return err
+		}
+		if result.Marker != marker {
+			return fmt.Errorf("Unexpected Marker: wanted %v, got %v", marker, result.Marker)
+		}
+		size := listPageSize(maxKeys, len(expected)-listed)
+		page := expected[listed : listed+size]
+		if err := verifyListEntries(result.Contents, result.CommonPrefixes, page); err != nil {
+			return fmt.Errorf("marker=%q: %v", marker, err)
+		}
+		listed += size
+		if result.IsTruncated != (listed < len(expected)) {
+			return fmt.Errorf("marker=%q: Unexpected IsTruncated: wanted %v, got %v", marker, listed < len(expected), result.IsTruncated)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		last := page[len(page)-1].key
+		// NextMarker is only required when a delimiter is set, otherwise the last key is the marker.
+		if delimiter != "" && result.NextMarker != last {
+			return fmt.Errorf("Unexpected NextMarker: wanted %v, got %v", last, result.NextMarker)
+		}
+		if result.NextMarker != "" && result.NextMarker != last {
+			return fmt.Errorf("Unexpected NextMarker: wanted %v, got %v", last, result.NextMarker)
+		}
+		marker = last
+	}
+	return nil
+}
+
+// mainListObjectsV1Pagination - Entry point for the ListObjects V1 pagination and parameter test.
+func mainListObjectsV1Pagination(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] ListObjects V1 (Pagination):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyListBucket.Name
+	if err := verifyListBucketPrepared(); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// max-keys boundaries, anything above 1000 must be treated as 1000.
+	for _, maxKeys := range []int{0, 1, maxListKeys, 2 * maxListKeys} {
+		// Spin scanBar
+		scanBar(message)
+		if err := listObjectsV1MaxKeys(config, bucketName, maxKeys); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Walk the listing with and without a delimiter, including delimiters nested several levels deep.
+	walks := []struct {
+		prefix    string
+		delimiter string
+		maxKeys   int
+	}{
+		{"", "", 300},
+		{"", "/", 2},
+		{"nested/", "/", 1},
+		{"nested/a/", "/", 1},
+		{"nested/a/b/", "/", 1},
+	}
+	for _, walk := range walks {
+		// Spin scanBar
+		scanBar(message)
+		if err := listObjectsV1Walk(config, bucketName, walk.prefix, walk.delimiter, walk.maxKeys); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// A marker in the middle of the listing must only return the keys after it.
+	// Spin scanBar
+	scanBar(message)
+	marker := listObjects[numListObjects/2].Key
+	result, err := listObjectsV1Page(config, bucketName, map[string]string{
+		"marker": marker,
+	})
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	expected := expectedListEntries(listObjects, "", "", marker)
+	if err := verifyListEntries(result.Contents, result.CommonPrefixes, expected); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,243 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// listObjectsV2Page - Request a single page of a ListObjects V2 listing and decode the result.
+func listObjectsV2Page(config ServerConfig, bucketName string, parameters map[string]string) (listBucketV2Result, error) {
+	req, err := newListObjectsV2Req(bucketName, parameters)
+	if err != nil {
+		return listBucketV2Result{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return listBucketV2Result{}, err
+	}
+	defer closeResponse(res)
+	// Verify the status and headers before decoding.
+	if err := verifyStatusListObjectsV2(res.StatusCode, http.StatusOK); err != nil {
+		return listBucketV2Result{}, err
+	}
+	if err := verifyHeaderListObjectsV2(res.Header); err != nil {
+		return listBucketV2Result{}, err
+	}
+	result := listBucketV2Result{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return listBucketV2Result{}, err
+	}
+	return result, nil
+}
+
+// verifyKeyCount - KeyCount must hold the number of keys and common prefixes actually returned.
+func verifyKeyCount(result listBucketV2Result) error {
+	if keyCount := len(result.Contents) + len(result.CommonPrefixes); result.KeyCount != keyCount {
+		return fmt.Errorf("Unexpected KeyCount: wanted %d, got %d", keyCount, result.KeyCount)
+	}
+	return nil
+}
+
+// listObjectsV2MaxKeys - Verify a single listing of the whole bucket with max-keys set.
+func listObjectsV2MaxKeys(config ServerConfig, bucketName string, maxKeys int) error {
+	result, err := listObjectsV2Page(config, bucketName, map[string]string{
+		"max-keys": strconv.Itoa(maxKeys),
+	})
+	if err != nil {
+		return err
+	}
+	expected := expectedListEntries(listObjects, "", "", "")
+	size := listPageSize(maxKeys, len(expected))
+	if err := verifyListEntries(result.Contents, result.CommonPrefixes, expected[:size]); err != nil {
+		return fmt.Errorf("max-keys=%d: %v", maxKeys, err)
+	}
+	if err := verifyKeyCount(result); err != nil {
+		return fmt.Errorf("max-keys=%d: %v", maxKeys, err)
+	}
+	// Whether an empty page is truncated differs between servers so it is not checked.
+	if maxKeys > 0 && result.IsTruncated != (size < len(expected)) {
+		return fmt.Errorf("max-keys=%d: Unexpected IsTruncated: wanted %v, got %v", maxKeys, size < len(expected), result.IsTruncated)
+	}
+	return nil
+}
+
+// listObjectsV2Walk - Walk every page of a listing following the continuation token and verify each page key by key.
+func listObjectsV2Walk(config ServerConfig, bucketName, prefix, delimiter, startAfter string, maxKeys int) error {
+	expected := expectedListEntries(listObjects, prefix, delimiter, startAfter)
+	listed := 0
+	continuationToken := ""
+	for {
+		parameters := map[string]string{
+			"max-keys": strconv.Itoa(maxKeys),
+		}
+		if prefix != "" {
+			parameters["prefix"] = prefix
+		}
+		if delimiter != "" {
+			parameters["delimiter"] = delimiter
+		}
+		if startAfter != "" {
+			parameters["start-after"] = startAfter
+		}
+		if continuationToken != "" {
+			parameters["continuation-token"] = continuationToken
+		}
+		result, err := listObjectsV2Page(config, bucketName, parameters)
+		if err != nil {
+			return err
+		}
+		if result.ContinuationToken != continuationToken {
+			return fmt.Errorf("Unexpected ContinuationToken: wanted %v, got %v", continuationToken, result.ContinuationToken)
+		}
+		if result.StartAfter != startAfter {
+			return fmt.Errorf("Unexpected StartAfter: wanted %v, got %v", startAfter, result.StartAfter)
+		}
+		size := listPageSize(maxKeys, len(expected)-listed)
+		if err := verifyListEntries(result.Contents, result.CommonPrefixes, expected[listed:listed+size]); err != nil {
+			return fmt.Errorf("after %d entries: %v", listed, err)
+		}
+		if err := verifyKeyCount(result); err != nil {
+			return err
+		}
+		listed += size
+		if result.IsTruncated != (listed < len(expected)) {
+			return fmt.Errorf("after %d entries: Unexpected IsTruncated: wanted %v, got %v", listed, listed < len(expected), result.IsTruncated)
+		}
+		if !result.IsTruncated {
+			break
+		}
+		if result.NextContinuationToken == "" {
+			return fmt.Errorf("Truncated listing did not return a NextContinuationToken")
+		}
+		continuationToken = result.NextContinuationToken
+	}
+	return nil
+}
+
+// listObjectsV2FetchOwner - Owners are only listed when fetch-owner is set.
+func listObjectsV2FetchOwner(config ServerConfig, bucketName string, fetchOwner bool) error {
+	result, err := listObjectsV2Page(config, bucketName, map[string]string{
+		"max-keys":    "5",
+		"fetch-owner": strconv.FormatBool(fetchOwner),
+	})
+	if err != nil {
+		return err
+	}
+	for _, object := range result.Contents {
+		if fetchOwner && object.Owner.ID == "" {
+			return fmt.Errorf("fetch-owner=true: no Owner listed for %s", object.Key)
+		}
+		if !fetchOwner && object.Owner.ID != "" {
+			return fmt.Errorf("fetch-owner=false: Unexpected Owner %s listed for %s", object.Owner.ID, object.Key)
+		}
+	}
+	return nil
+}
+
+// listObjectsV2EncodingType - Keys returned with encoding-type=url must decode back to the keys uploaded.
+func listObjectsV2EncodingType(config ServerConfig, bucketName string) error {
+	prefix := "encode/"
+	result, err := listObjectsV2Page(config, bucketName, map[string]string{
+		"prefix":        prefix,
+		"encoding-type": "url",
+	})
+	if err != nil {
+		return err
+	}
+	if result.EncodingType != "url" {
+		return fmt.Errorf("Unexpected EncodingType: wanted url, got %v", result.EncodingType)
+	}
+	for i, object := range result.Contents {
+		if strings.Contains(object.Key, " ") {
+			return fmt.Errorf("Key was not url encoded: %q", object.Key)
+		}
+		key, err := url.QueryUnescape(object.Key)
+		if err != nil {
+			return err
+		}
+		result.Contents[i].Key = key
+	}
+	return verifyListEntries(result.Contents, result.CommonPrefixes, expectedListEntries(listObjects, prefix, "", ""))
+}
+
+// mainListObjectsV2Pagination - Entry point for the ListObjects V2 pagination and parameter test.
+func mainListObjectsV2Pagination(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] ListObjects V2 (Pagination):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyListBucket.Name
+	if err := verifyListBucketPrepared(); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// max-keys boundaries, anything above 1000 must be treated as 1000.
+	for _, maxKeys := range []int{0, 1, maxListKeys, 2 * maxListKeys} {
+		// Spin scanBar
+		scanBar(message)
+		if err := listObjectsV2MaxKeys(config, bucketName, maxKeys); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Walk the listing with continuation tokens, start-after and nested delimiters.
+	walks := []struct {
+		prefix     string
+		delimiter  string
+		startAfter string
+		maxKeys    int
+	}{
+		{"", "", "", 300},
+		{"", "", listObjects[numListObjects/2].Key, 100},
+		{"", "/", "", 2},
+		{"nested/", "/", "", 1},
+		{"nested/a/", "/", "", 1},
+		{"nested/a/b/", "/", "", 1},
+	}
+	for _, walk := range walks {
+		// Spin scanBar
+		scanBar(message)
+		if err := listObjectsV2Walk(config, bucketName, walk.prefix, walk.delimiter, walk.startAfter, walk.maxKeys); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	for _, fetchOwner := range []bool{true, false} {
+		// Spin scanBar
+		scanBar(message)
+		if err := listObjectsV2FetchOwner(config, bucketName, fetchOwner); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := listObjectsV2EncodingType(config, bucketName); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
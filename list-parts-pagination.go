@@ -0,0 +1,192 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// listPartsPaginationTotalParts - number of parts uploaded for the ListParts pagination test.
+const listPartsPaginationTotalParts = 11
+
+// listPartsPaginationMaxParts - number of parts requested per page.
+const listPartsPaginationMaxParts = 3
+
+// newListPartsPageReq - Create a new HTTP request for a single page of the ListParts API.
+func newListPartsPageReq(bucketName, objectName, uploadID string, maxParts, partNumberMarker int) (Request, error) {
+	listPartsPageReq, err := newListPartsReq(bucketName, objectName, uploadID)
+	if err != nil {
+		return Request{}, err
+	}
+	listPartsPageReq.queryValues.Set("max-parts", strconv.Itoa(maxParts))
+	if partNumberMarker > 0 {
+		listPartsPageReq.queryValues.Set("part-number-marker", strconv.Itoa(partNumberMarker))
+	}
+	return listPartsPageReq, nil
+}
+
+// listPartsPage - Request a single page of parts and decode the result.
+func listPartsPage(config ServerConfig, bucketName, objectName, uploadID string, maxParts, partNumberMarker int) (listObjectPartsResult, error) {
+	req, err := newListPartsPageReq(bucketName, objectName, uploadID, maxParts, partNumberMarker)
+	if err != nil {
+		return listObjectPartsResult{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return listObjectPartsResult{}, err
+	}
+	defer closeResponse(res)
+	// Verify the status and headers before decoding.
+	if err := verifyStatusListParts(res.StatusCode, http.StatusOK); err != nil {
+		return listObjectPartsResult{}, err
+	}
+	if err := verifyHeaderListParts(res.Header); err != nil {
+		return listObjectPartsResult{}, err
+	}
+	result := listObjectPartsResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return listObjectPartsResult{}, err
+	}
+	return result, nil
+}
+
+// verifyListPartsPage - verify a single page of parts against the parts that were uploaded.
+func verifyListPartsPage(result listObjectPartsResult, partNumberMarker int, etags []string) error {
+	// Part numbers are 1-indexed so the first part on this page directly follows the marker.
+	first := partNumberMarker + 1
+	last := first + listPartsPaginationMaxParts - 1
+	if last > len(etags) {
+		last = len(etags)
+	}
+	if len(result.ObjectParts) != last-first+1 {
+		err := fmt.Errorf("Unexpected number of parts after marker %d: wanted %d, got %d", partNumberMarker, last-first+1, len(result.ObjectParts))
+		return err
+	}
+	for i, part := range result.ObjectParts {
+		if part.PartNumber != first+i {
+			err := fmt.Errorf("Unexpected part number: wanted %d, got %d", first+i, part.PartNumber)
+			return err
+		}
+		if strings.Trim(part.ETag, "\"") != etags[part.PartNumber-1] {
+			err := fmt.Errorf("Unexpected ETag for part %d: wanted %v, got %v", part.PartNumber, etags[part.PartNumber-1], part.ETag)
+			return err
+		}
+	}
+	isTruncated := last < len(etags)
+	if result.IsTruncated != isTruncated {
+		err := fmt.Errorf("Unexpected IsTruncated after marker %d: wanted %v, got %v", partNumberMarker, isTruncated, result.IsTruncated)
+		return err
+	}
+	if isTruncated && result.NextPartNumberMarker != last {
+		err := fmt.Errorf("Unexpected NextPartNumberMarker: wanted %d, got %d", last, result.NextPartNumberMarker)
+		return err
+	}
+	if result.MaxParts != listPartsPaginationMaxParts {
+		err := fmt.Errorf("Unexpected MaxParts: wanted %d, got %d", listPartsPaginationMaxParts, result.MaxParts)
+		return err
+	}
+	return nil
+}
+
+// mainListPartsPagination - Entry point for the ListParts pagination test.
+func mainListPartsPagination(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (List-Parts Pagination):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objectName := "s3verify/multipart/list-parts"
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before aborting it itself.
+	aborted := false
+	defer func() {
+		if !aborted {
+			abortMultipartUpload(config, bucketName, objectName, uploadID)
+		}
+	}()
+	// Upload many small parts, only the final size of a completed upload is restricted.
+	etags := []string{}
+	for partNumber := 1; partNumber <= listPartsPaginationTotalParts; partNumber++ {
+		// Spin scanBar
+		scanBar(message)
+		partData := []byte(fmt.Sprintf("s3verify list parts pagination part %d", partNumber))
+		etag, err := uploadPart(config, bucketName, objectName, uploadID, partNumber, partData)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		etags = append(etags, etag)
+	}
+	// Walk every page following NextPartNumberMarker.
+	partNumberMarker := 0
+	pages := 0
+	for {
+		// Spin scanBar
+		scanBar(message)
+		result, err := listPartsPage(config, bucketName, objectName, uploadID, listPartsPaginationMaxParts, partNumberMarker)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifyListPartsPage(result, partNumberMarker, etags); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		pages++
+		if !result.IsTruncated {
+			break
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+	expectedPages := (listPartsPaginationTotalParts + listPartsPaginationMaxParts - 1) / listPartsPaginationMaxParts
+	if pages != expectedPages {
+		err := fmt.Errorf("Unexpected number of pages: wanted %d, got %d", expectedPages, pages)
+		printMessage(message, err)
+		return false
+	}
+	// A marker at the last part must return an empty, untruncated page.
+	// Spin scanBar
+	scanBar(message)
+	result, err := listPartsPage(config, bucketName, objectName, uploadID, listPartsPaginationMaxParts, listPartsPaginationTotalParts)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if len(result.ObjectParts) != 0 || result.IsTruncated {
+		err := fmt.Errorf("Unexpected parts after the last part: wanted 0, got %d (truncated: %v)", len(result.ObjectParts), result.IsTruncated)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Remove the upload and all of its parts.
+	if err := abortMultipartUpload(config, bucketName, objectName, uploadID); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	aborted = true
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
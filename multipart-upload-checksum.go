@@ -0,0 +1,220 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// newInitiateMultipartUploadChecksumReq - Create a new HTTP request for a multipart upload using a checksum algorithm.
+func newInitiateMultipartUploadChecksumReq(bucketName, objectName string, a checksumAlgorithm) (Request, error) {
+	initiateMultipartUploadReq, err := newInitiateMultipartUploadReq(bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	initiateMultipartUploadReq.customHeader.Set(checksumAlgorithmHeader, a.name)
+	if a.fullObject {
+		initiateMultipartUploadReq.customHeader.Set(checksumTypeHeader, "FULL_OBJECT")
+	}
+	return initiateMultipartUploadReq, nil
+}
+
+// newUploadPartChecksumReq - Create a new HTTP request for an upload part request with an additional checksum header.
+func newUploadPartChecksumReq(bucketName, objectName, uploadID string, partNumber int, partData []byte, a checksumAlgorithm, checksum string) (Request, error) {
+	uploadPartReq, err := newUploadPartReq(bucketName, objectName, uploadID, partNumber, partData)
+	if err != nil {
+		return Request{}, err
+	}
+	uploadPartReq.customHeader.Set(a.header(), checksum)
+	uploadPartReq.customHeader.Set(sdkChecksumAlgorithmHeader, a.name)
+	return uploadPartReq, nil
+}
+
+// initiateMultipartUploadChecksum - Start a multipart upload using a checksum algorithm and return its uploadID.
+func initiateMultipartUploadChecksum(config ServerConfig, bucketName, objectName string, a checksumAlgorithm) (string, error) {
+	req, err := newInitiateMultipartUploadChecksumReq(bucketName, objectName, a)
+	if err != nil {
+		return "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	uploadID, err := initiateMultipartUploadVerify(res, http.StatusOK)
+	if err != nil {
+		return "", err
+	}
+	if algorithm := res.Header.Get(checksumAlgorithmHeader); algorithm != a.name {
+		err := fmt.Errorf("Unexpected %s Received: wanted %v, got %v", checksumAlgorithmHeader, a.name, algorithm)
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// uploadPartChecksum - Upload a part with the given checksum either as a header or as a trailer
+// and return its ETag. A non empty expectedCode means the upload must fail.
+func uploadPartChecksum(config ServerConfig, bucketName, objectName, uploadID string, partNumber int, partData []byte, a checksumAlgorithm, checksum string, trailing bool, expectedStatusCode int, expectedCode string) (string, error) {
+	req, err := newUploadPartChecksumReq(bucketName, objectName, uploadID, partNumber, partData, a, checksum)
+	if err != nil {
+		return "", err
+	}
+	if trailing {
+		setTrailingChecksum(&req, partData, a, checksum)
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if expectedCode != "" {
+		return "", verifyChecksumErrorResponse(res, expectedStatusCode, expectedCode)
+	}
+	if err := uploadPartVerify(res, expectedStatusCode); err != nil {
+		return "", err
+	}
+	if err := verifyChecksumHeader(res.Header, a, checksum); err != nil {
+		return "", err
+	}
+	return strings.Trim(res.Header.Get("ETag"), "\""), nil
+}
+
+// completeMultipartUploadChecksum - Complete a multipart upload and verify the checksum of the object returned.
+func completeMultipartUploadChecksum(config ServerConfig, bucketName, objectName, uploadID string, complete *completeMultipartUpload, a checksumAlgorithm, expectedChecksum string) error {
+	req, err := newCompleteMultipartUploadReq(bucketName, objectName, uploadID, complete)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyStatusCompleteMultipartUpload(res.StatusCode, http.StatusOK); err != nil {
+		return err
+	}
+	result := completeMultipartUploadResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return err
+	}
+	if checksum := result.get(a); checksum != expectedChecksum {
+		err := fmt.Errorf("Unexpected Checksum%s Received: wanted %v, got %v", a.name, expectedChecksum, checksum)
+		return err
+	}
+	return nil
+}
+
+// mainMultipartUploadChecksum - Entry point for the multipart upload additional checksum test.
+func mainMultipartUploadChecksum(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Checksums):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Every part but the last must be at least 5MB.
+	parts := [][]byte{make([]byte, 5*1024*1024), make([]byte, 1024)}
+	for _, part := range parts {
+		if _, err := io.ReadFull(crand.Reader, part); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	for _, a := range checksumAlgorithms {
+		// Spin scanBar
+		scanBar(message)
+		object := &ObjectInfo{
+			Key:  "s3verify/checksum/multipart/" + a.name,
+			Body: append(append([]byte{}, parts[0]...), parts[1]...),
+		}
+		uploadID, err := initiateMultipartUploadChecksum(config, bucketName, object.Key, a)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Abort the upload if the test fails before completing it.
+		completed := false
+		defer func() {
+			if !completed {
+				abortMultipartUpload(config, bucketName, object.Key, uploadID)
+			}
+		}()
+		complete := &completeMultipartUpload{}
+		for i, part := range parts {
+			// Spin scanBar
+			scanBar(message)
+			checksum := a.checksum(part)
+			// Send the checksum of the last part as a trailer.
+			trailing := i == len(parts)-1
+			etag, err := uploadPartChecksum(config, bucketName, object.Key, uploadID, i+1, part, a, checksum, trailing, http.StatusOK, "")
+			if err != nil {
+				err := fmt.Errorf("UploadPart %d of %s: %v", i+1, object.Key, err)
+				printMessage(message, err)
+				return false
+			}
+			complPart := completePart{
+				PartNumber: i + 1,
+				ETag:       etag,
+			}
+			complPart.set(a, checksum)
+			complete.Parts = append(complete.Parts, complPart)
+		}
+		// Spin scanBar
+		scanBar(message)
+		// A part whose checksum does not match its data must be rejected.
+		for _, trailing := range []bool{false, true} {
+			if _, err := uploadPartChecksum(config, bucketName, object.Key, uploadID, len(parts)+1, parts[1], a, a.checksum([]byte("s3verify")), trailing, http.StatusBadRequest, "BadDigest"); err != nil {
+				err := fmt.Errorf("UploadPart %d of %s: %v", len(parts)+1, object.Key, err)
+				printMessage(message, err)
+				return false
+			}
+		}
+		// Spin scanBar
+		scanBar(message)
+		// Composite checksums are a checksum of the part checksums, full object checksums cover the whole object.
+		objectChecksum := a.multipartChecksum(parts)
+		if err := completeMultipartUploadChecksum(config, bucketName, object.Key, uploadID, complete, a, objectChecksum); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		completed = true
+		// Save the object so it is removed later.
+		checksumObjects = append(checksumObjects, object)
+		// Spin scanBar
+		scanBar(message)
+		for _, method := range []string{"HEAD", "GET"} {
+			if err := getObjectChecksumMode(config, method, bucketName, object, a, objectChecksum); err != nil {
+				err := fmt.Errorf("%s %s: %v", method, object.Key, err)
+				printMessage(message, err)
+				return false
+			}
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
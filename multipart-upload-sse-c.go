@@ -0,0 +1,230 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// newInitiateMultipartUploadSSECReq - Create a new HTTP request to initiate a multipart upload encrypted with a customer provided key.
+func newInitiateMultipartUploadSSECReq(bucketName, objectName string, key []byte) (Request, error) {
+	initiateMultipartUploadSSECReq, err := newInitiateMultipartUploadReq(bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSECustomerHeaders(initiateMultipartUploadSSECReq.customHeader, key)
+
+	return initiateMultipartUploadSSECReq, nil
+}
+
+// newUploadPartSSECReq - Create a new HTTP request to upload a part encrypted with a customer provided key.
+func newUploadPartSSECReq(bucketName, objectName, uploadID string, partNumber int, partData, key []byte) (Request, error) {
+	uploadPartSSECReq, err := newUploadPartReq(bucketName, objectName, uploadID, partNumber, partData)
+	if err != nil {
+		return Request{}, err
+	}
+	// A nil key sends the request without any SSE-C headers.
+	if key != nil {
+		setSSECustomerHeaders(uploadPartSSECReq.customHeader, key)
+	}
+
+	return uploadPartSSECReq, nil
+}
+
+// newCompleteMultipartUploadSSECReq - Create a new HTTP request to complete a multipart upload encrypted with a customer provided key.
+func newCompleteMultipartUploadSSECReq(bucketName, objectName, uploadID string, complete *completeMultipartUpload, key []byte) (Request, error) {
+	completeMultipartUploadSSECReq, err := newCompleteMultipartUploadReq(bucketName, objectName, uploadID, complete)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSECustomerHeaders(completeMultipartUploadSSECReq.customHeader, key)
+
+	return completeMultipartUploadSSECReq, nil
+}
+
+// verifyInsecureMultipartUploadSSEC - Verify that an SSE-C multipart upload is refused over plain HTTP.
+func verifyInsecureMultipartUploadSSEC(config ServerConfig, bucketName, objectName string, key []byte) error {
+	req, err := newInitiateMultipartUploadSSECReq(bucketName, objectName, key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	if err := verifyStatusInitiateMultipartUpload(res.StatusCode, http.StatusBadRequest); err != nil {
+		return err
+	}
+	return verifySSEErrorResponse(res.Body, errSSECustomerInsecure)
+}
+
+// uploadPartsSSEC - Upload a 5MB part followed by a smaller last part encrypted with key and complete the upload.
+func uploadPartsSSEC(config ServerConfig, bucketName string, object *ObjectInfo, key []byte) error {
+	complete := &completeMultipartUpload{}
+	for partNumber, partSize := range []int{5 * 1024 * 1024, 1024} {
+		partData := make([]byte, partSize)
+		if _, err := io.ReadFull(crand.Reader, partData); err != nil {
+			return err
+		}
+		// Parts of an SSE-C upload without the key must be refused.
+		noKeyReq, err := newUploadPartSSECReq(bucketName, object.Key, object.UploadID, partNumber+1, partData, nil)
+		if err != nil {
+			return err
+		}
+		// Execute the request.
+		noKeyRes, err := config.execRequest("PUT", noKeyReq)
+		if err != nil {
+			return err
+		}
+		defer closeResponse(noKeyRes)
+		if err := verifyStatusUploadPart(noKeyRes.StatusCode, http.StatusBadRequest); err != nil {
+			return err
+		}
+		if err := verifySSEErrorResponse(noKeyRes.Body, ErrorResponse{Code: "InvalidRequest"}); err != nil {
+			return err
+		}
+		// Upload the part with the key.
+		partReq, err := newUploadPartSSECReq(bucketName, object.Key, object.UploadID, partNumber+1, partData, key)
+		if err != nil {
+			return err
+		}
+		// Execute the request.
+		partRes, err := config.execRequest("PUT", partReq)
+		if err != nil {
+			return err
+		}
+		defer closeResponse(partRes)
+		// Verify the response.
+		if err := uploadPartVerify(partRes, http.StatusOK); err != nil {
+			return err
+		}
+		if err := verifySSECustomerHeaders(partRes.Header, key); err != nil {
+			return err
+		}
+		// Store the part to be completed.
+		complete.Parts = append(complete.Parts, completePart{
+			PartNumber: partNumber + 1,
+			ETag:       strings.Trim(partRes.Header.Get("ETag"), "\""),
+		})
+		object.Body = append(object.Body, partData...)
+	}
+	// Complete the upload.
+	completeReq, err := newCompleteMultipartUploadSSECReq(bucketName, object.Key, object.UploadID, complete, key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	completeRes, err := config.execRequest("POST", completeReq)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(completeRes)
+	// Verify the response.
+	return completeMultipartUploadVerify(completeRes, http.StatusOK)
+}
+
+// mainMultipartUploadSSEC - Entry point for the multipart upload test with a customer provided key.
+func mainMultipartUploadSSEC(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (SSE-C):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	key, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	object := &ObjectInfo{
+		Key: "s3verify/sse-c/multipart",
+	}
+	if !isSecureEndpoint(config.Endpoint) {
+		// Over plain HTTP the upload must be refused before it is initiated.
+		if err := verifyInsecureMultipartUploadSSEC(config, bucketName, object.Key, key); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// Initiate the encrypted upload.
+	req, err := newInitiateMultipartUploadSSECReq(bucketName, object.Key, key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response and get the uploadID.
+	object.UploadID, err = initiateMultipartUploadVerify(res, http.StatusOK)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifySSECustomerHeaders(res.Header, key); err != nil {
+		// Abort the upload so it is not left behind.
+		abortMultipartUpload(config, bucketName, object.Key, object.UploadID)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := uploadPartsSSEC(config, bucketName, object, key); err != nil {
+		// Abort the upload so its parts are not left behind.
+		abortMultipartUpload(config, bucketName, object.Key, object.UploadID)
+		printMessage(message, err)
+		return false
+	}
+	// Save the object so it is removed later.
+	sseCustomerObjects = append(sseCustomerObjects, object)
+	// Spin scanBar
+	scanBar(message)
+	// The completed object must decrypt to the concatenated parts.
+	getReq, err := newGetObjectSSECReq(bucketName, object.Key, key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Verify the response.
+	if err := getObjectSSECVerify(getRes, http.StatusOK, object.Body, key, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,172 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	crand "crypto/rand"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// newInitiateMultipartUploadSSEReq - Create a new HTTP request to initiate a multipart upload with SSE-S3 or SSE-KMS.
+func newInitiateMultipartUploadSSEReq(bucketName, objectName, algorithm, kmsKeyID string) (Request, error) {
+	initiateMultipartUploadSSEReq, err := newInitiateMultipartUploadReq(bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSEHeaders(initiateMultipartUploadSSEReq.customHeader, algorithm, kmsKeyID)
+
+	return initiateMultipartUploadSSEReq, nil
+}
+
+// mainMultipartUploadSSE - Entry point for the multipart upload test with SSE-S3 and SSE-KMS.
+func mainMultipartUploadSSE(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (SSE-S3/SSE-KMS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	multipartSSEObjects := []*ObjectInfo{
+		&ObjectInfo{
+			Key:                  "s3verify/sse/s3-multipart",
+			ServerSideEncryption: sseAlgorithmAES256,
+		},
+		&ObjectInfo{
+			Key:                  "s3verify/sse/kms-multipart",
+			ServerSideEncryption: sseAlgorithmKMS,
+			KMSKeyID:             config.KMSKeyID,
+		},
+	}
+	for _, object := range multipartSSEObjects {
+		// Spin scanBar
+		scanBar(message)
+		// The encryption is requested when the upload is initiated.
+		req, err := newInitiateMultipartUploadSSEReq(bucketName, object.Key, object.ServerSideEncryption, object.KMSKeyID)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("POST", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response and get the uploadID.
+		object.UploadID, err = initiateMultipartUploadVerify(res, http.StatusOK)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifySSEHeaders(res.Header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+		// Upload a single part, every part inherits the encryption of the upload.
+		object.Body = make([]byte, 1024)
+		if _, err := io.ReadFull(crand.Reader, object.Body); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partReq, err := newUploadPartReq(bucketName, object.Key, object.UploadID, 1, object.Body)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		partRes, err := config.execRequest("PUT", partReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(partRes)
+		// Verify the response.
+		if err := uploadPartVerify(partRes, http.StatusOK); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifySSEHeaders(partRes.Header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		complete := &completeMultipartUpload{
+			Parts: []completePart{
+				completePart{
+					PartNumber: 1,
+					ETag:       strings.Trim(partRes.Header.Get("ETag"), "\""),
+				},
+			},
+		}
+		// Spin scanBar
+		scanBar(message)
+		// Complete the upload.
+		completeReq, err := newCompleteMultipartUploadReq(bucketName, object.Key, object.UploadID, complete)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		completeRes, err := config.execRequest("POST", completeReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(completeRes)
+		// Verify the response.
+		if err := completeMultipartUploadVerify(completeRes, http.StatusOK); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifySSEHeaders(completeRes.Header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Save the object so it is removed later.
+		sseObjects = append(sseObjects, object)
+		// Spin scanBar
+		scanBar(message)
+		// The completed object must report the encryption of its upload.
+		headReq, err := newHeadObjectReq(bucketName, object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		headRes, err := config.execRequest("HEAD", headReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(headRes)
+		// Verify the response.
+		if err := headObjectSSEVerify(headRes, http.StatusOK, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
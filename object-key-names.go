@@ -0,0 +1,307 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Longest object key S3 accepts in bytes.
+const maxKeyLength = 1024
+
+// keyName - An object key that exercises a key encoding path.
+type keyName struct {
+	description string
+	key         string
+	// Control characters can not be represented in XML so the key can only be listed with encoding-type=url.
+	control bool
+}
+
+// Holds all objects created by the key name tests.
+var keyNameObjects = []*ObjectInfo{}
+
+// keyNames - Keys with characters that need to be encoded in paths, headers, signatures and listings.
+var keyNames = []keyName{
+	{description: "unicode", key: "s3verify/keys/unicode-日本語-ключ-ü-😀"},
+	{description: "spaces", key: "s3verify/keys/with spaces  in it"},
+	{description: "plus", key: "s3verify/keys/plus+sign+"},
+	{description: "percent", key: "s3verify/keys/percent%20not-a-space%"},
+	{description: "question mark", key: "s3verify/keys/question?mark=1&b"},
+	{description: "hash", key: "s3verify/keys/hash#fragment"},
+	{description: "double slash", key: "s3verify/keys//double//slash"},
+	{description: "leading slash", key: "/s3verify/keys/leading-slash"},
+	{description: "trailing dots", key: "s3verify/keys/trailing-dots..."},
+	{description: "control characters", key: "s3verify/keys/control-\x01\x07\x1f", control: true},
+	{description: "1024 bytes", key: "s3verify/keys/long-" + strings.Repeat("k", maxKeyLength-len("s3verify/keys/long-"))},
+}
+
+// verifyKeyNameErrorResponse - verify that the error returned for a key matches what is expected.
+func verifyKeyNameErrorResponse(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// headKeyName - HEAD an object and verify its size and ETag, or that it no longer exists.
+func headKeyName(config ServerConfig, bucketName string, object *ObjectInfo, expectedStatusCode int) error {
+	req, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := headObjectVerify(res, expectedStatusCode); err != nil {
+		return err
+	}
+	if expectedStatusCode != http.StatusOK {
+		return nil
+	}
+	if res.Header.Get("Content-Length") != strconv.Itoa(len(object.Body)) {
+		err := fmt.Errorf("Unexpected Content-Length: wanted %d, got %v", len(object.Body), res.Header.Get("Content-Length"))
+		return err
+	}
+	md5Sum := md5.Sum(object.Body)
+	if etag := strings.Trim(res.Header.Get("ETag"), "\""); etag != hex.EncodeToString(md5Sum[:]) {
+		err := fmt.Errorf("Unexpected ETag: wanted %v, got %v", hex.EncodeToString(md5Sum[:]), etag)
+		return err
+	}
+	return nil
+}
+
+// copyKeyName - Copy an object so the source key is sent encoded in the x-amz-copy-source header.
+func copyKeyName(config ServerConfig, bucketName string, source, dest *ObjectInfo) error {
+	req, err := newCopyObjectReq(bucketName, source.Key, bucketName, dest.Key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := copyObjectVerify(res, http.StatusOK); err != nil {
+		return err
+	}
+	// The copy must hold the data of the source.
+	return verifyObjectBody(config, bucketName, dest)
+}
+
+// listKeyName - List an object by using its key as the prefix, with or without encoding-type=url.
+func listKeyName(config ServerConfig, bucketName, key string, encoded bool) error {
+	parameters := map[string]string{
+		"prefix":   key,
+		"max-keys": "1",
+	}
+	if encoded {
+		parameters["encoding-type"] = "url"
+	}
+	result, err := listObjectsV2Page(config, bucketName, parameters)
+	if err != nil {
+		return err
+	}
+	if len(result.Contents) != 1 {
+		err := fmt.Errorf("Unexpected Number of Objects Listed: wanted 1, got %d", len(result.Contents))
+		return err
+	}
+	listedKey := result.Contents[0].Key
+	if encoded {
+		if listedKey, err = url.QueryUnescape(listedKey); err != nil {
+			return err
+		}
+	}
+	if listedKey != key {
+		err := fmt.Errorf("Incorrect Key Received (encoding-type=url: %v): wanted %q, got %q", encoded, key, result.Contents[0].Key)
+		return err
+	}
+	return nil
+}
+
+// presignedKeyName - Overwrite an object through a presigned PUT and read it back through a presigned GET.
+func presignedKeyName(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	body := []byte(randString(60, rand.NewSource(time.Now().UnixNano()), ""))
+	putURL, err := newPresignedPutObjectReq(config, bucketName, object.Key, time.Minute)
+	if err != nil {
+		return err
+	}
+	putReq, err := http.NewRequest("PUT", putURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	putRes, err := config.Client.Do(putReq)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(putRes)
+	// Verify the response.
+	if err := presignedPutObjectVerify(putRes, http.StatusOK, ErrorResponse{}); err != nil {
+		return err
+	}
+	object.Body = body
+	getURL, err := newGetObjectPresignedReq(config, bucketName, object.Key, time.Minute, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	getRes, err := config.Client.Get(getURL.String())
+	if err != nil {
+		return err
+	}
+	defer closeResponse(getRes)
+	// Verify the response.
+	return getObjectPresignedVerify(getRes, http.StatusOK, object.Body, ErrorResponse{})
+}
+
+// keyNameRoundTrip - PUT, HEAD, GET, COPY, LIST and DELETE a single object key.
+func keyNameRoundTrip(config ServerConfig, bucketName string, object, copyObject *ObjectInfo, control bool) error {
+	if err := putObject(config, bucketName, object); err != nil {
+		return fmt.Errorf("PUT: %v", err)
+	}
+	if err := headKeyName(config, bucketName, object, http.StatusOK); err != nil {
+		return fmt.Errorf("HEAD: %v", err)
+	}
+	if err := verifyObjectBody(config, bucketName, object); err != nil {
+		return fmt.Errorf("GET: %v", err)
+	}
+	if err := copyKeyName(config, bucketName, object, copyObject); err != nil {
+		return fmt.Errorf("COPY: %v", err)
+	}
+	if !control {
+		if err := listKeyName(config, bucketName, object.Key, false); err != nil {
+			return fmt.Errorf("LIST: %v", err)
+		}
+	}
+	if err := listKeyName(config, bucketName, object.Key, true); err != nil {
+		return fmt.Errorf("LIST: %v", err)
+	}
+	if err := presignedKeyName(config, bucketName, object); err != nil {
+		return fmt.Errorf("Presigned: %v", err)
+	}
+	if err := removeObject(config, bucketName, object.Key); err != nil {
+		return fmt.Errorf("DELETE: %v", err)
+	}
+	// The object must really be gone.
+	if err := headKeyName(config, bucketName, object, http.StatusNotFound); err != nil {
+		return fmt.Errorf("HEAD after DELETE: %v", err)
+	}
+	return removeObject(config, bucketName, copyObject.Key)
+}
+
+// mainObjectKeyNames - Entry point for the object key name test.
+func mainObjectKeyNames(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Object Key Names:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for i, keyName := range keyNames {
+		// Spin scanBar
+		scanBar(message)
+		object := &ObjectInfo{
+			Key:  keyName.key,
+			Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+		}
+		copyObject := &ObjectInfo{
+			Key:  "s3verify/keys-copy/" + strconv.Itoa(i),
+			Body: object.Body,
+		}
+		// Save the objects so they are removed later if any step fails.
+		keyNameObjects = append(keyNameObjects, object, copyObject)
+		if err := keyNameRoundTrip(config, bucketName, object, copyObject, keyName.control); err != nil {
+			err := fmt.Errorf("%s key %q: %v", keyName.description, keyName.key, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
+
+// mainObjectKeyNameTooLong - Entry point for the object key name test with a key longer than 1024 bytes.
+func mainObjectKeyNameTooLong(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Object Key Names (Too Long):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	object := &ObjectInfo{
+		Key:  "s3verify/keys/too-long-" + strings.Repeat("k", maxKeyLength+1-len("s3verify/keys/too-long-")),
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	// Create a new request.
+	req, err := newPutObjectReq(bucketName, object.Key, object.Body)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Save the object so it is removed later if the server wrongly created it.
+	if res.StatusCode == http.StatusOK {
+		keyNameObjects = append(keyNameObjects, object)
+	}
+	// Verify the response.
+	expectedError := ErrorResponse{
+		Code: "KeyTooLongError",
+	}
+	if err := verifyKeyNameErrorResponse(res, http.StatusBadRequest, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
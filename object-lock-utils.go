@@ -0,0 +1,163 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Object lock headers.
+const (
+	objectLockEnabledHeader     = "X-Amz-Bucket-Object-Lock-Enabled"
+	objectLockModeHeader        = "X-Amz-Object-Lock-Mode"
+	objectLockRetainUntilHeader = "X-Amz-Object-Lock-Retain-Until-Date"
+	objectLockLegalHoldHeader   = "X-Amz-Object-Lock-Legal-Hold"
+	bypassGovernanceHeader      = "X-Amz-Bypass-Governance-Retention"
+	versionIDHeader             = "X-Amz-Version-Id"
+
+	// Retention modes.
+	objectLockModeGovernance = "GOVERNANCE"
+	objectLockModeCompliance = "COMPLIANCE"
+
+	// Legal hold statuses.
+	legalHoldOn  = "ON"
+	legalHoldOff = "OFF"
+)
+
+// Compliance retention cannot be lifted so it is kept short enough for the bucket to be removed at the end of the run.
+const complianceRetentionPeriod = time.Minute
+
+// Extra time given for the clocks of s3verify and the server to disagree when waiting for compliance retention to expire.
+const complianceRetentionMargin = 5 * time.Second
+
+var (
+	// The bucket created with object lock enabled.
+	s3verifyObjectLockBucket BucketInfo
+
+	// Holds all object versions uploaded to the object lock enabled bucket.
+	objectLockObjects = []*ObjectInfo{}
+)
+
+// newObjectLockRetainUntil - Retention dates only carry seconds.
+func newObjectLockRetainUntil(retention time.Duration) time.Time {
+	return time.Now().UTC().Add(retention).Truncate(time.Second)
+}
+
+// putObjectLockObject - Upload a new object version to the object lock enabled bucket and store its version.
+func putObjectLockObject(config ServerConfig, objectName string) (*ObjectInfo, error) {
+	object := &ObjectInfo{
+		Key:  objectName,
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	req, err := newPutObjectReq(s3verifyObjectLockBucket.Name, object.Key, object.Body)
+	if err != nil {
+		return nil, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return nil, err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := putObjectVerify(res, http.StatusOK); err != nil {
+		return nil, err
+	}
+	// Object lock applies to versions so every upload must create one.
+	object.VersionID = res.Header.Get(versionIDHeader)
+	if object.VersionID == "" {
+		err := fmt.Errorf("Unexpected %s Received: wanted a version ID, got none", versionIDHeader)
+		return nil, err
+	}
+	// Save the version so it is removed later.
+	objectLockObjects = append(objectLockObjects, object)
+	return object, nil
+}
+
+// verifyObjectLockHeaders - Verify that the retention of an object version is reported in its headers.
+func verifyObjectLockHeaders(header http.Header, mode string, retainUntil time.Time) error {
+	if receivedMode := header.Get(objectLockModeHeader); receivedMode != mode {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", objectLockModeHeader, mode, receivedMode)
+		return err
+	}
+	receivedRetainUntil, err := time.Parse(time.RFC3339, header.Get(objectLockRetainUntilHeader))
+	if err != nil {
+		return err
+	}
+	// A zero retainUntil only requires the retention to still be in effect.
+	if retainUntil.IsZero() {
+		if !receivedRetainUntil.After(time.Now()) {
+			err := fmt.Errorf("Unexpected %s Received: wanted a future date, got %s", objectLockRetainUntilHeader, receivedRetainUntil)
+			return err
+		}
+		return nil
+	}
+	if !receivedRetainUntil.Equal(retainUntil) {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", objectLockRetainUntilHeader, retainUntil, receivedRetainUntil)
+		return err
+	}
+	return nil
+}
+
+// newRemoveObjectVersionReq - Create a new DELETE object HTTP request for a single object version.
+func newRemoveObjectVersionReq(config ServerConfig, bucketName, objectName, versionID string, bypassGovernance bool) (Request, error) {
+	removeObjectVersionReq, err := newRemoveObjectReq(config, bucketName, objectName)
+	if err != nil {
+		return Request{}, err
+	}
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("versionId", versionID)
+	removeObjectVersionReq.queryValues = urlValues
+	if bypassGovernance {
+		removeObjectVersionReq.customHeader.Set(bypassGovernanceHeader, "true")
+	}
+
+	return removeObjectVersionReq, nil
+}
+
+// removeObjectVersionVerify - Verify that the response returned matches what is expected.
+func removeObjectVersionVerify(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if err := verifyStatusRemoveObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderRemoveObject(res.Header); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		return verifyObjectLockErrorResponse(res.Body, expectedError)
+	}
+	return verifyBodyRemoveObject(res.Body)
+}
+
+// verifyObjectLockErrorResponse - Verify that the error returned for an object lock request matches what is expected.
+func verifyObjectLockErrorResponse(resBody io.Reader, expectedError ErrorResponse) error {
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(resBody, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// corsPreflight - a CORS preflight request and the response it should produce.
+type corsPreflight struct {
+	origin         string // Origin of the preflight request.
+	method         string // Access-Control-Request-Method of the preflight request.
+	headers        string // Access-Control-Request-Headers of the preflight request.
+	expectedStatus int
+
+	allowOrigin   string   // Expected Access-Control-Allow-Origin.
+	exposeHeaders []string // Headers expected in Access-Control-Expose-Headers.
+	maxAge        string   // Expected Access-Control-Max-Age.
+}
+
+// Preflight requests checked against the rules in s3verifyCors.
+var corsPreflights = []corsPreflight{
+	// Explicitly allowed origin with request headers matched by a wildcard.
+	corsPreflight{
+		origin:         "http://www.s3verify.com",
+		method:         "PUT",
+		headers:        "content-type, x-amz-meta-s3verify",
+		expectedStatus: http.StatusOK,
+		allowOrigin:    "http://www.s3verify.com",
+		exposeHeaders:  []string{"ETag", "x-amz-request-id"},
+		maxAge:         "3000",
+	},
+	// Origin matched by a wildcard subdomain.
+	corsPreflight{
+		origin:         "http://sub.s3verify.com",
+		method:         "GET",
+		expectedStatus: http.StatusOK,
+		allowOrigin:    "http://sub.s3verify.com",
+	},
+	// Any origin is allowed to HEAD.
+	corsPreflight{
+		origin:         "http://www.example.com",
+		method:         "HEAD",
+		expectedStatus: http.StatusOK,
+		allowOrigin:    "*",
+	},
+	// Origin is not allowed to use this method.
+	corsPreflight{
+		origin:         "http://www.example.com",
+		method:         "PUT",
+		expectedStatus: http.StatusForbidden,
+	},
+	// Request header not allowed by the matching rule.
+	corsPreflight{
+		origin:         "http://sub.s3verify.com",
+		method:         "GET",
+		headers:        "x-amz-meta-s3verify",
+		expectedStatus: http.StatusForbidden,
+	},
+}
+
+// newOptionsObjectReq - Create a new unsigned HTTP request for a CORS preflight.
+func newOptionsObjectReq(bucketName, objectName string, preflight corsPreflight) (Request, error) {
+	// optionsObjectReq - a new HTTP request for an OPTIONS object.
+	var optionsObjectReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	optionsObjectReq.bucketName = bucketName
+	optionsObjectReq.objectName = objectName
+
+	// Browsers never sign preflight requests.
+	optionsObjectReq.anonymous = true
+
+	// Set the headers.
+	optionsObjectReq.customHeader.Set("Origin", preflight.origin)
+	optionsObjectReq.customHeader.Set("Access-Control-Request-Method", preflight.method)
+	if preflight.headers != "" {
+		optionsObjectReq.customHeader.Set("Access-Control-Request-Headers", preflight.headers)
+	}
+	optionsObjectReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return optionsObjectReq, nil
+}
+
+// optionsObjectVerify - Verify that the response returned matches what is expected.
+func optionsObjectVerify(res *http.Response, preflight corsPreflight) error {
+	if err := verifyStatusOptionsObject(res.StatusCode, preflight.expectedStatus); err != nil {
+		return err
+	}
+	if err := verifyHeaderOptionsObject(res.Header, preflight); err != nil {
+		return err
+	}
+	if err := verifyBodyOptionsObject(res.Body, preflight.expectedStatus); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusOptionsObject - Verify that the status returned matches what is expected.
+func verifyStatusOptionsObject(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderOptionsObject - Verify that the CORS headers returned match the preflight request.
+func verifyHeaderOptionsObject(header http.Header, preflight corsPreflight) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if preflight.expectedStatus != http.StatusOK {
+		// A refused preflight must not grant access to the origin.
+		if allowOrigin := header.Get("Access-Control-Allow-Origin"); allowOrigin != "" {
+			err := fmt.Errorf("Unexpected Access-Control-Allow-Origin Received: wanted none, got %s", allowOrigin)
+			return err
+		}
+		return nil
+	}
+	if allowOrigin := header.Get("Access-Control-Allow-Origin"); allowOrigin != preflight.allowOrigin {
+		err := fmt.Errorf("Unexpected Access-Control-Allow-Origin Received: wanted %s, got %s", preflight.allowOrigin, allowOrigin)
+		return err
+	}
+	if allowMethods := header.Get("Access-Control-Allow-Methods"); !headerListContains(allowMethods, preflight.method) {
+		err := fmt.Errorf("Unexpected Access-Control-Allow-Methods Received: wanted %s to be allowed, got %s", preflight.method, allowMethods)
+		return err
+	}
+	allowHeaders := header.Get("Access-Control-Allow-Headers")
+	for _, requestHeader := range strings.Split(preflight.headers, ",") {
+		requestHeader = strings.TrimSpace(requestHeader)
+		if requestHeader != "" && !headerListContains(allowHeaders, requestHeader) {
+			err := fmt.Errorf("Unexpected Access-Control-Allow-Headers Received: wanted %s to be allowed, got %s", requestHeader, allowHeaders)
+			return err
+		}
+	}
+	exposeHeaders := header.Get("Access-Control-Expose-Headers")
+	for _, exposeHeader := range preflight.exposeHeaders {
+		if !headerListContains(exposeHeaders, exposeHeader) {
+			err := fmt.Errorf("Unexpected Access-Control-Expose-Headers Received: wanted %s to be exposed, got %s", exposeHeader, exposeHeaders)
+			return err
+		}
+	}
+	if preflight.maxAge != "" {
+		if maxAge := header.Get("Access-Control-Max-Age"); maxAge != preflight.maxAge {
+			err := fmt.Errorf("Unexpected Access-Control-Max-Age Received: wanted %s, got %s", preflight.maxAge, maxAge)
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBodyOptionsObject - Verify that a refused preflight returns the correct error.
+func verifyBodyOptionsObject(resBody io.Reader, expectedStatusCode int) error {
+	if expectedStatusCode == http.StatusOK {
+		return nil
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(resBody, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != "AccessForbidden" {
+		err := fmt.Errorf("Unexpected Error Code: wanted AccessForbidden, got %s", receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// headerListContains - Check whether a comma separated header value contains the given element.
+func headerListContains(headerValue, element string) bool {
+	for _, value := range strings.Split(headerValue, ",") {
+		if strings.EqualFold(strings.TrimSpace(value), element) {
+			return true
+		}
+	}
+	return false
+}
+
+// mainOptionsObject - Entry point for the CORS preflight (OPTIONS object) test.
+func mainOptionsObject(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] OptionsObject (CORS Preflight):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// CORS was configured on the first s3verify created bucket by the PutBucketCors test.
+	bucketName := s3verifyBuckets[0].Name
+	object := s3verifyObjects[0]
+	for _, preflight := range corsPreflights {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newOptionsObjectReq(bucketName, object.Key, preflight)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("OPTIONS", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := optionsObjectVerify(res, preflight); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
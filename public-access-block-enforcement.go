@@ -0,0 +1,71 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// mainPublicAccessBlockEnforcement - Entry point for the test of requests rejected by the public access block.
+func mainPublicAccessBlockEnforcement(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PublicAccessBlock (Enforcement):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The ownership controls were removed so ACLs are enabled again and only the public access block applies.
+	bucketName := s3verifyAccessBucket.Name
+	denied := ErrorResponse{Code: "AccessDenied"}
+	// BlockPublicAcls rejects public ACLs on objects and on the bucket.
+	public := &ObjectInfo{
+		Key:  "s3verify/access/public-read",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	if err := putAccessObject(config, public, cannedACLs[1].name, http.StatusForbidden, denied); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := putAccessBucketACL(config, cannedACLs[2].name, http.StatusForbidden, denied); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Private ACLs are not affected.
+	private := &ObjectInfo{
+		Key:  "s3verify/access/private",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	if err := putAccessObject(config, private, cannedACLs[0].name, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// BlockPublicPolicy rejects policies granting access to everyone.
+	if err := putBucketPolicy(config, bucketName, newPublicReadPolicy(bucketName), http.StatusForbidden, denied); err != nil {
+		err := fmt.Errorf("PUT bucket policy: %v", err)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
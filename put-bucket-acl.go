@@ -0,0 +1,201 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newPutBucketACLReq - Create a new HTTP request for the PutBucketAcl API.
+func newPutBucketACLReq(bucketName, acl string, policy *accessControlPolicy) (Request, error) {
+	// putBucketACLReq - a new HTTP request for the PutBucketAcl API.
+	var putBucketACLReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketACLReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+	putBucketACLReq.queryValues = urlValues
+
+	// The ACL is either sent as a canned ACL header or as an AccessControlPolicy body.
+	policyBytes := []byte{}
+	if policy != nil {
+		var err error
+		policyBytes, err = xml.Marshal(policy)
+		if err != nil {
+			return Request{}, err
+		}
+	}
+	reader := bytes.NewReader(policyBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketACLReq.contentBody = reader
+	putBucketACLReq.contentLength = contentLength
+	if acl != "" {
+		putBucketACLReq.customHeader.Set(aclHeader, acl)
+	}
+	putBucketACLReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putBucketACLReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketACLReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketACLReq, nil
+}
+
+// putBucketACLVerify - Verify that the response returned matches what is expected.
+func putBucketACLVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutBucketACL(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutBucketACL(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutBucketACL(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutBucketACL - Verify that the status returned matches what is expected.
+func verifyStatusPutBucketACL(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutBucketACL - Verify that the header returned matches what is expected.
+func verifyHeaderPutBucketACL(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutBucketACL - Verify that the body returned is empty.
+func verifyBodyPutBucketACL(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// putBucketACL - Set the ACL of a bucket, reporting whether the server implements it.
+func putBucketACL(config ServerConfig, bucketName, acl string, policy *accessControlPolicy) (bool, error) {
+	req, err := newPutBucketACLReq(bucketName, acl, policy)
+	if err != nil {
+		return false, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return false, err
+	}
+	defer closeResponse(res)
+	if notImplemented, err := isACLNotImplemented(res); notImplemented || err != nil {
+		return false, err
+	}
+	// Verify the response.
+	if err := putBucketACLVerify(res, http.StatusOK); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mainPutBucketACL - Entry point for the PutBucketAcl API test.
+func mainPutBucketACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucketAcl:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyACLBucket.Name
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Every canned ACL that is accepted must be applied.
+	for _, acl := range cannedACLs {
+		// Spin scanBar
+		scanBar(message)
+		implemented, err := putBucketACL(config, bucketName, acl.name, nil)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if !implemented {
+			continue
+		}
+		if err := verifyBucketACL(config, bucketName, aclOwner, cannedACLGrants(aclOwner, acl)); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Set the same grants as public-read through an AccessControlPolicy.
+	expectedGrants := cannedACLGrants(aclOwner, cannedACLs[1])
+	policy := &accessControlPolicy{
+		Owner: aclOwner,
+		AccessControlList: accessControlList{
+			Grants: expectedGrants,
+		},
+	}
+	implemented, err := putBucketACL(config, bucketName, "", policy)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if implemented {
+		if err := verifyBucketACL(config, bucketName, aclOwner, expectedGrants); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Make the bucket private again.
+	if _, err := putBucketACL(config, bucketName, cannedACLs[0].name, nil); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,100 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// newPutBucketCannedACLReq - Create a new Make bucket request with a canned ACL.
+func newPutBucketCannedACLReq(region, bucketName, acl string) (Request, error) {
+	putBucketCannedACLReq, err := newPutBucketReq(region, bucketName)
+	if err != nil {
+		return Request{}, err
+	}
+	putBucketCannedACLReq.customHeader.Set(aclHeader, acl)
+
+	return putBucketCannedACLReq, nil
+}
+
+// putBucketCannedACL - Create a bucket with a canned ACL, reporting whether the server implements it.
+func putBucketCannedACL(config ServerConfig, bucketName, acl string) (bool, error) {
+	req, err := newPutBucketCannedACLReq(config.Region, bucketName, acl)
+	if err != nil {
+		return false, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return false, err
+	}
+	defer closeResponse(res)
+	if notImplemented, err := isACLNotImplemented(res); notImplemented || err != nil {
+		return false, err
+	}
+	// Verify the response.
+	if err := putBucketVerify(res, bucketName, http.StatusOK, ErrorResponse{}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mainPutBucketCannedACL - Entry point for the PutBucket test with a canned ACL.
+func mainPutBucketCannedACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucket (Canned ACL):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucket := BucketInfo{
+		Name: "s3verify-" + globalSuffix + "-acl",
+	}
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	acl := cannedACLs[1] // public-read
+	implemented, err := putBucketCannedACL(config, bucket.Name, acl.name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if !implemented {
+		// The remaining ACL tests still need a bucket to run against.
+		if _, err := putBucketCannedACL(config, bucket.Name, cannedACLs[0].name); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		acl = cannedACLs[0]
+	}
+	// Save the bucket so it is removed later.
+	s3verifyACLBucket = bucket
+	// Spin scanBar
+	scanBar(message)
+	// The ACL must have been applied when the bucket was created.
+	if err := verifyBucketACL(config, bucket.Name, aclOwner, cannedACLGrants(aclOwner, acl)); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,169 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// The CORS configuration applied to the first s3verify created bucket.
+var s3verifyCors = corsConfiguration{
+	CORSRules: []corsRule{
+		// Explicit origin allowed to GET and PUT with any request header.
+		corsRule{
+			AllowedOrigins: []string{"http://www.s3verify.com"},
+			AllowedMethods: []string{"GET", "PUT"},
+			AllowedHeaders: []string{"*"},
+			ExposeHeaders:  []string{"ETag", "x-amz-request-id"},
+			MaxAgeSeconds:  3000,
+		},
+		// Wildcard subdomain origin allowed to GET.
+		corsRule{
+			AllowedOrigins: []string{"http://*.s3verify.com"},
+			AllowedMethods: []string{"GET"},
+		},
+		// Any origin allowed to HEAD.
+		corsRule{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"HEAD"},
+		},
+	},
+}
+
+// newPutBucketCorsReq - Create a new HTTP request for the PutBucketCors API.
+func newPutBucketCorsReq(bucketName string, cors corsConfiguration) (Request, error) {
+	// putBucketCorsReq - a new HTTP request for the PutBucketCors API.
+	var putBucketCorsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketCorsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("cors", "")
+	putBucketCorsReq.queryValues = urlValues
+
+	corsBytes, err := xml.Marshal(cors)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(corsBytes)
+	// Content-MD5 is required for PutBucketCors requests.
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketCorsReq.contentBody = reader
+	putBucketCorsReq.contentLength = contentLength
+	putBucketCorsReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putBucketCorsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketCorsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketCorsReq, nil
+}
+
+// putBucketCorsVerify - Verify that the response returned matches what is expected.
+func putBucketCorsVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutBucketCors(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutBucketCors(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutBucketCors(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutBucketCors - Verify that the status returned matches what is expected.
+func verifyStatusPutBucketCors(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutBucketCors - Verify that the header returned matches what is expected.
+func verifyHeaderPutBucketCors(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutBucketCors - Verify that the body returned is empty.
+func verifyBodyPutBucketCors(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutBucketCors - Entry point for the PutBucketCors API test.
+func mainPutBucketCors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucketCors:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// CORS is only configured on s3verify created buckets.
+	bucketName := s3verifyBuckets[0].Name
+	// Create a new request.
+	req, err := newPutBucketCorsReq(bucketName, s3verifyCors)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := putBucketCorsVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
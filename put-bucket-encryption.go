@@ -0,0 +1,157 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newBucketEncryption - Create a default encryption configuration with a single rule.
+func newBucketEncryption(algorithm, kmsKeyID string) serverSideEncryptionConfiguration {
+	return serverSideEncryptionConfiguration{
+		Rules: []serverSideEncryptionRule{
+			serverSideEncryptionRule{
+				ApplyServerSideEncryptionByDefault: applyServerSideEncryptionByDefault{
+					SSEAlgorithm:   algorithm,
+					KMSMasterKeyID: kmsKeyID,
+				},
+			},
+		},
+	}
+}
+
+// newPutBucketEncryptionReq - Create a new HTTP request for the PutBucketEncryption API.
+func newPutBucketEncryptionReq(bucketName string, encryption serverSideEncryptionConfiguration) (Request, error) {
+	// putBucketEncryptionReq - a new HTTP request for the PutBucketEncryption API.
+	var putBucketEncryptionReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketEncryptionReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+	putBucketEncryptionReq.queryValues = urlValues
+
+	encryptionBytes, err := xml.Marshal(encryption)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(encryptionBytes)
+	// Content-MD5 is required for PutBucketEncryption requests.
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketEncryptionReq.contentBody = reader
+	putBucketEncryptionReq.contentLength = contentLength
+	putBucketEncryptionReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putBucketEncryptionReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketEncryptionReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketEncryptionReq, nil
+}
+
+// putBucketEncryptionVerify - Verify that the response returned matches what is expected.
+func putBucketEncryptionVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutBucketEncryption(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutBucketEncryption(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutBucketEncryption(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutBucketEncryption - Verify that the status returned matches what is expected.
+func verifyStatusPutBucketEncryption(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutBucketEncryption - Verify that the header returned matches what is expected.
+func verifyHeaderPutBucketEncryption(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutBucketEncryption - Verify that the body returned is empty.
+func verifyBodyPutBucketEncryption(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// putBucketEncryption - Apply a default encryption configuration to a bucket.
+func putBucketEncryption(config ServerConfig, bucketName string, encryption serverSideEncryptionConfiguration) error {
+	req, err := newPutBucketEncryptionReq(bucketName, encryption)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return putBucketEncryptionVerify(res, http.StatusOK)
+}
+
+// mainPutBucketEncryption - Entry point for the PutBucketEncryption API test.
+func mainPutBucketEncryption(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucketEncryption:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Default encryption is only configured on the second s3verify created bucket.
+	bucketName := s3verifyBuckets[1].Name
+	if err := putBucketEncryption(config, bucketName, newBucketEncryption(sseAlgorithmAES256, "")); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
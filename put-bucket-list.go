@@ -0,0 +1,62 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// mainPutBucketList - Entry point for creating the bucket used by the listing pagination tests.
+func mainPutBucketList(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucket (Listing):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucket := BucketInfo{
+		Name: "s3verify-" + globalSuffix + "-list",
+	}
+	if err := putBucket(config, bucket.Name); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the bucket so it is removed later.
+	s3verifyListBucket = bucket
+	objectNames := []string{}
+	for i := 0; i < numListObjects; i++ {
+		objectNames = append(objectNames, fmt.Sprintf("flat/%04d", i))
+	}
+	objectNames = append(objectNames, nestedListObjectKeys...)
+	objectNames = append(objectNames, encodedListObjectKeys...)
+	for _, objectName := range objectNames {
+		// Spin scanBar
+		scanBar(message)
+		object := newListObject(objectName)
+		if err := putObject(config, bucket.Name, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Save the object so it is removed later.
+		listObjects = append(listObjects, *object)
+	}
+	// Listings are always returned in key order.
+	sort.Sort(listObjects)
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
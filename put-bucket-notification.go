@@ -0,0 +1,168 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// Prefix and suffix of the keys the s3verify notification configuration sends events for.
+const (
+	notificationPrefix = "s3verify/notification/"
+	notificationSuffix = ".txt"
+)
+
+// newS3verifyNotification - The notification configuration sending object created and removed events to the given target.
+func newS3verifyNotification(targetARN string) notificationConfiguration {
+	return notificationConfiguration{
+		QueueConfigurations: []queueConfiguration{
+			queueConfiguration{
+				ID:     "s3verify-notification",
+				Queue:  targetARN,
+				Events: []string{"s3:ObjectCreated:*", "s3:ObjectRemoved:*"},
+				Filter: notificationFilter{
+					S3Key: notificationKeyFilter{
+						FilterRules: []filterRule{
+							filterRule{Name: "prefix", Value: notificationPrefix},
+							filterRule{Name: "suffix", Value: notificationSuffix},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newPutBucketNotificationReq - Create a new HTTP request for the PutBucketNotificationConfiguration API.
+func newPutBucketNotificationReq(bucketName string, notification notificationConfiguration) (Request, error) {
+	// putBucketNotificationReq - a new HTTP request for the PutBucketNotificationConfiguration API.
+	var putBucketNotificationReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketNotificationReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("notification", "")
+	putBucketNotificationReq.queryValues = urlValues
+
+	notificationBytes, err := xml.Marshal(notification)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(notificationBytes)
+	_, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketNotificationReq.contentBody = reader
+	putBucketNotificationReq.contentLength = contentLength
+	putBucketNotificationReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketNotificationReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketNotificationReq, nil
+}
+
+// putBucketNotification - Set the notification configuration of a bucket and verify the response.
+// A non empty expectedError code means the configuration must be rejected.
+func putBucketNotification(config ServerConfig, bucketName string, notification notificationConfiguration, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutBucketNotificationReq(bucketName, notification)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if expectedError.Code != "" {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) != 0 {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutBucketNotification - Entry point for the PutBucketNotificationConfiguration API test.
+func mainPutBucketNotification(config ServerConfig, curTest int) bool {
+	// Only servers with a webhook target are tested, the test name reports when the test is skipped.
+	testName := "PutBucketNotification"
+	if config.WebhookARN == "" {
+		testName = "PutBucketNotification (Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	if config.WebhookARN == "" {
+		// Test skipped.
+		printMessage(message, nil)
+		return true
+	}
+	// Notifications are only configured on s3verify created buckets.
+	bucketName := s3verifyBuckets[0].Name
+	// A target the server does not know about must be rejected.
+	unknownTarget := newS3verifyNotification("arn:aws:sqs:" + config.Region + ":000000000000:s3verify-missing")
+	if err := putBucketNotification(config, bucketName, unknownTarget, http.StatusBadRequest, ErrorResponse{Code: "InvalidArgument"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := putBucketNotification(config, bucketName, newS3verifyNotification(config.WebhookARN), http.StatusOK, ErrorResponse{}); err != nil {
+		err := fmt.Errorf("%v (is the server configured with the webhook target %s?)", err, config.WebhookARN)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
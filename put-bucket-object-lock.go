@@ -0,0 +1,93 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// newPutBucketObjectLockReq - Create a new Make bucket request with object lock enabled.
+func newPutBucketObjectLockReq(region, bucketName string) (Request, error) {
+	// Object lock can only be enabled when the bucket is created.
+	putBucketObjectLockReq, err := newPutBucketReq(region, bucketName)
+	if err != nil {
+		return Request{}, err
+	}
+	putBucketObjectLockReq.customHeader.Set(objectLockEnabledHeader, "true")
+
+	return putBucketObjectLockReq, nil
+}
+
+// mainPutBucketObjectLock - Entry point for the PutBucket test with object lock enabled.
+func mainPutBucketObjectLock(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucket (Object Lock):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucket := BucketInfo{
+		Name: "s3verify-" + globalSuffix + "-lock",
+	}
+	// Create a new request.
+	req, err := newPutBucketObjectLockReq(config.Region, bucket.Name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := putBucketVerify(res, bucket.Name, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the bucket so it is removed later.
+	s3verifyObjectLockBucket = bucket
+	// Spin scanBar
+	scanBar(message)
+	// A new bucket has object lock enabled without any default retention.
+	getReq, err := newGetObjectLockConfigurationReq(bucket.Name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Verify the response.
+	if err := getObjectLockConfigurationVerify(getRes, http.StatusOK, objectLockConfiguration{ObjectLockEnabled: "Enabled"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,160 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newOwnershipControls - Ownership controls holding a single rule.
+func newOwnershipControls(objectOwnership string) ownershipControls {
+	return ownershipControls{
+		Rules: []ownershipControlsRule{
+			{ObjectOwnership: objectOwnership},
+		},
+	}
+}
+
+// newPutBucketOwnershipControlsReq - Create a new HTTP request for the PutBucketOwnershipControls API.
+func newPutBucketOwnershipControlsReq(bucketName string, controls ownershipControls) (Request, error) {
+	// putBucketOwnershipControlsReq - a new HTTP request for the PutBucketOwnershipControls API.
+	var putBucketOwnershipControlsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketOwnershipControlsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+	putBucketOwnershipControlsReq.queryValues = urlValues
+
+	controlsBytes, err := xml.Marshal(controls)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(controlsBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketOwnershipControlsReq.contentBody = reader
+	putBucketOwnershipControlsReq.contentLength = contentLength
+	putBucketOwnershipControlsReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putBucketOwnershipControlsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketOwnershipControlsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketOwnershipControlsReq, nil
+}
+
+// putBucketOwnershipControls - Set the ownership controls of a bucket and verify the response.
+// A non empty expectedError code means the controls must be rejected.
+func putBucketOwnershipControls(config ServerConfig, bucketName string, controls ownershipControls, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutBucketOwnershipControlsReq(bucketName, controls)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		return nil
+	}
+	return verifyEmptyBody(res)
+}
+
+// mainPutBucketOwnershipControls - Entry point for the PutBucketOwnershipControls API test.
+func mainPutBucketOwnershipControls(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucketOwnershipControls:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Access controls are set on a bucket of their own so they do not leak into other tests.
+	bucket := BucketInfo{
+		Name: "s3verify-" + globalSuffix + "-access",
+	}
+	if err := putBucket(config, bucket.Name); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the bucket so it is removed later.
+	s3verifyAccessBucket = bucket
+	// Every setting must be accepted and returned as set, the last one is kept for the checks below.
+	for _, objectOwnership := range []string{objectOwnershipObjectWriter, objectOwnershipBucketOwnerPreferred, objectOwnershipBucketOwnerEnforced} {
+		// Spin scanBar
+		scanBar(message)
+		controls := newOwnershipControls(objectOwnership)
+		if err := putBucketOwnershipControls(config, bucket.Name, controls, http.StatusOK, ErrorResponse{}); err != nil {
+			err := fmt.Errorf("%s: %v", objectOwnership, err)
+			printMessage(message, err)
+			return false
+		}
+		if err := getBucketOwnershipControls(config, bucket.Name, controls, http.StatusOK, ErrorResponse{}); err != nil {
+			err := fmt.Errorf("%s: %v", objectOwnership, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// BucketOwnerEnforced disables ACLs, any request granting access beyond the owner is rejected.
+	rejected := &ObjectInfo{
+		Key:  "s3verify/access/public-read",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	if err := putAccessObject(config, rejected, cannedACLs[1].name, http.StatusBadRequest, ErrorResponse{Code: "AccessControlListNotSupported"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := putAccessBucketACL(config, cannedACLs[1].name, http.StatusBadRequest, ErrorResponse{Code: "AccessControlListNotSupported"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The bucket owner already owns every object so bucket-owner-full-control is still accepted.
+	accepted := &ObjectInfo{
+		Key:  "s3verify/access/bucket-owner-full-control",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	if err := putAccessObject(config, accepted, bucketOwnerFullControlACL, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,132 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newPublicReadPolicy - A bucket policy letting anyone read every object in the bucket.
+func newPublicReadPolicy(bucketName string) []byte {
+	return []byte(fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Sid":"s3verify","Effect":"Allow","Principal":"*","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName))
+}
+
+// newPutBucketPolicyReq - Create a new HTTP request for the PutBucketPolicy API.
+func newPutBucketPolicyReq(bucketName string, policy []byte) (Request, error) {
+	// putBucketPolicyReq - a new HTTP request for the PutBucketPolicy API.
+	var putBucketPolicyReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketPolicyReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("policy", "")
+	putBucketPolicyReq.queryValues = urlValues
+
+	reader := bytes.NewReader(policy)
+	_, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketPolicyReq.contentBody = reader
+	putBucketPolicyReq.contentLength = contentLength
+	putBucketPolicyReq.customHeader.Set("Content-Type", "application/json")
+	putBucketPolicyReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketPolicyReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketPolicyReq, nil
+}
+
+// putBucketPolicy - Attach a policy to a bucket and verify the response.
+// A non empty expectedError code means the policy must be rejected.
+func putBucketPolicy(config ServerConfig, bucketName string, policy []byte, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutBucketPolicyReq(bucketName, policy)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, expectedStatusCode, expectedError); err != nil {
+		return err
+	}
+	if expectedError.Code != "" {
+		return nil
+	}
+	return verifyEmptyBody(res)
+}
+
+// newRemoveBucketPolicyReq - Create a new HTTP request for the DeleteBucketPolicy API.
+func newRemoveBucketPolicyReq(bucketName string) (Request, error) {
+	// removeBucketPolicyReq - a new HTTP request for the DeleteBucketPolicy API.
+	var removeBucketPolicyReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removeBucketPolicyReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("policy", "")
+	removeBucketPolicyReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removeBucketPolicyReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removeBucketPolicyReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removeBucketPolicyReq, nil
+}
+
+// removeBucketPolicy - Remove the policy attached to a bucket.
+func removeBucketPolicy(config ServerConfig, bucketName string) error {
+	req, err := newRemoveBucketPolicyReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != http.StatusNoContent {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusNoContent, res.StatusCode)
+		return err
+	}
+	return nil
+}
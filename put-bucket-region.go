@@ -0,0 +1,190 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// otherRegion - A region other than the one the server is in.
+func otherRegion(region string) string {
+	if region == "us-west-2" {
+		return "eu-west-1"
+	}
+	return "us-west-2"
+}
+
+// verifyRegionErrorResponse - Verify that a request made for the wrong region failed as expected.
+func verifyRegionErrorResponse(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if err := verifyStatusPutBucket(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	// The region the request should have been made for is reported back when expected.
+	if expectedError.Region != "" && receivedError.Region != expectedError.Region {
+		err := fmt.Errorf("Unexpected Error Region: wanted %s, got %s", expectedError.Region, receivedError.Region)
+		return err
+	}
+	return nil
+}
+
+// mainPutBucketRegion - Entry point for the PutBucket test in the configured region and in mismatched regions.
+func mainPutBucketRegion(config ServerConfig, curTest int) bool {
+	// Buckets can be created in any region from us-east-1 so a mismatched location is only refused elsewhere,
+	// the test name reports when that check is skipped.
+	testName := "PutBucket (Region)"
+	if config.Region == globalDefaultRegion {
+		testName = "PutBucket (Region, Mismatch Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := "s3verify-" + globalSuffix + "-region"
+	// Create a bucket in the configured region.
+	req, err := newPutBucketReq(config.Region, bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := putBucketVerify(res, bucketName, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The location read back must be the configured region.
+	getReq, err := newGetBucketLocationReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Verify the response.
+	if err := getBucketLocationVerify(getRes, http.StatusOK, bucketLocation(config.Region), ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Remove the bucket again.
+	removeReq, err := newRemoveBucketReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	removeRes, err := config.execRequest("DELETE", removeReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(removeRes)
+	// Verify the response.
+	if err := removeBucketVerify(removeRes, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// A location other than the region of the server must be refused along with the region of the server.
+	if config.Region != globalDefaultRegion {
+		expectedError := ErrorResponse{
+			Code:   "IllegalLocationConstraintException",
+			Region: config.Region,
+		}
+		mismatchReq, err := newPutBucketReq(otherRegion(config.Region), bucketName)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		mismatchRes, err := config.execRequest("PUT", mismatchReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(mismatchRes)
+		// Verify the request failed as expected.
+		if err := verifyRegionErrorResponse(mismatchRes, http.StatusBadRequest, expectedError); err != nil {
+			if mismatchRes.StatusCode == http.StatusOK {
+				// Remove the wrongly created bucket before reporting the failure.
+				removeBucket(config, bucketName)
+			}
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// A request signed for another region must be refused along with the region it should have been signed for.
+	expectedError := ErrorResponse{
+		Code:   "AuthorizationHeaderMalformed",
+		Region: config.Region,
+	}
+	wrongSignatureReq, err := newPutBucketReq(config.Region, bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	wrongSignatureReq.signingRegion = otherRegion(config.Region)
+	// Execute the request.
+	wrongSignatureRes, err := config.execRequest("PUT", wrongSignatureReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(wrongSignatureRes)
+	// Verify the request failed as expected.
+	if err := verifyRegionErrorResponse(wrongSignatureRes, http.StatusBadRequest, expectedError); err != nil {
+		if wrongSignatureRes.StatusCode == http.StatusOK {
+			// Remove the wrongly created bucket before reporting the failure.
+			removeBucket(config, bucketName)
+		}
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,173 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// The website configuration serving index and error documents, applied to the first s3verify created bucket.
+var s3verifyWebsite = websiteConfiguration{
+	IndexDocument: &websiteIndexDocument{Suffix: "index.html"},
+	ErrorDocument: &websiteErrorDocument{Key: "error.html"},
+	RoutingRules: &websiteRoutingRules{
+		RoutingRules: []websiteRoutingRule{
+			// Pages moved from old/ to new/.
+			websiteRoutingRule{
+				Condition: &websiteCondition{KeyPrefixEquals: "old/"},
+				Redirect:  websiteRedirect{ReplaceKeyPrefixWith: "new/"},
+			},
+			// Missing pages under gone/ redirect to the index.
+			websiteRoutingRule{
+				Condition: &websiteCondition{KeyPrefixEquals: "gone/", HTTPErrorCodeReturnedEquals: "404"},
+				Redirect:  websiteRedirect{ReplaceKeyWith: "index.html", HTTPRedirectCode: "302"},
+			},
+		},
+	},
+}
+
+// A website configuration redirecting every request to another host.
+var s3verifyWebsiteRedirectAll = websiteConfiguration{
+	RedirectAllRequestsTo: &websiteRedirect{
+		HostName: "www.s3verify.com",
+		Protocol: "https",
+	},
+}
+
+// newPutBucketWebsiteReq - Create a new HTTP request for the PutBucketWebsite API.
+func newPutBucketWebsiteReq(bucketName string, website websiteConfiguration) (Request, error) {
+	// putBucketWebsiteReq - a new HTTP request for the PutBucketWebsite API.
+	var putBucketWebsiteReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putBucketWebsiteReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("website", "")
+	putBucketWebsiteReq.queryValues = urlValues
+
+	websiteBytes, err := xml.Marshal(website)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(websiteBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putBucketWebsiteReq.contentBody = reader
+	putBucketWebsiteReq.contentLength = contentLength
+	putBucketWebsiteReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putBucketWebsiteReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putBucketWebsiteReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putBucketWebsiteReq, nil
+}
+
+// putBucketWebsite - Set the website configuration of a bucket and verify the response.
+// A non empty expectedError code means the configuration must be rejected.
+func putBucketWebsite(config ServerConfig, bucketName string, website websiteConfiguration, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutBucketWebsiteReq(bucketName, website)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if expectedError.Code != "" {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	if err := verifyStandardHeaders(res.Header); err != nil {
+		return err
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) != 0 {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutBucketWebsite - Entry point for the PutBucketWebsite API test.
+func mainPutBucketWebsite(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutBucketWebsite:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Websites are only configured on s3verify created buckets.
+	bucketName := s3verifyBuckets[0].Name
+	// The index document suffix can not contain a slash.
+	invalid := websiteConfiguration{
+		IndexDocument: &websiteIndexDocument{Suffix: "docs/index.html"},
+	}
+	if err := putBucketWebsite(config, bucketName, invalid, http.StatusBadRequest, ErrorResponse{Code: "InvalidArgument"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Redirect every request, then replace it with the configuration used by later tests.
+	if err := putBucketWebsite(config, bucketName, s3verifyWebsiteRedirectAll, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := getBucketWebsite(config, bucketName, s3verifyWebsiteRedirectAll, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := putBucketWebsite(config, bucketName, s3verifyWebsite, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -169,6 +169,22 @@ func verifyHeaderPutBucket(header http.Header, bucketName string, expectedStatus
 	return nil
 }
 
+// putBucket - Create a new bucket in the configured region.
+func putBucket(config ServerConfig, bucketName string) error {
+	req, err := newPutBucketReq(config.Region, bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return putBucketVerify(res, bucketName, http.StatusOK, ErrorResponse{})
+}
+
 // mainPutBucket- entry point for the putBucket test with valid names.
 func mainPutBucket(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] PutBucket (Valid Names):", curTest, globalTotalNumTest)
@@ -0,0 +1,204 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newPutObjectACLReq - Create a new HTTP request for the PutObjectAcl API.
+func newPutObjectACLReq(bucketName, objectName, acl string, policy *accessControlPolicy) (Request, error) {
+	// putObjectACLReq - a new HTTP request for the PutObjectAcl API.
+	var putObjectACLReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	putObjectACLReq.bucketName = bucketName
+	putObjectACLReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("acl", "")
+	putObjectACLReq.queryValues = urlValues
+
+	// The ACL is either sent as a canned ACL header or as an AccessControlPolicy body.
+	policyBytes := []byte{}
+	if policy != nil {
+		var err error
+		policyBytes, err = xml.Marshal(policy)
+		if err != nil {
+			return Request{}, err
+		}
+	}
+	reader := bytes.NewReader(policyBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putObjectACLReq.contentBody = reader
+	putObjectACLReq.contentLength = contentLength
+	if acl != "" {
+		putObjectACLReq.customHeader.Set(aclHeader, acl)
+	}
+	putObjectACLReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putObjectACLReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putObjectACLReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putObjectACLReq, nil
+}
+
+// putObjectACLVerify - Verify that the response returned matches what is expected.
+func putObjectACLVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutObjectACL(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectACL(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectACL(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutObjectACL - Verify that the status returned matches what is expected.
+func verifyStatusPutObjectACL(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectACL - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectACL(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutObjectACL - Verify that the body returned is empty.
+func verifyBodyPutObjectACL(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// putObjectACL - Set the ACL of an object, reporting whether the server implements it.
+func putObjectACL(config ServerConfig, bucketName, objectName, acl string, policy *accessControlPolicy) (bool, error) {
+	req, err := newPutObjectACLReq(bucketName, objectName, acl, policy)
+	if err != nil {
+		return false, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return false, err
+	}
+	defer closeResponse(res)
+	if notImplemented, err := isACLNotImplemented(res); notImplemented || err != nil {
+		return false, err
+	}
+	// Verify the response.
+	if err := putObjectACLVerify(res, http.StatusOK); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// mainPutObjectACL - Entry point for the PutObjectAcl API test.
+func mainPutObjectACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObjectAcl:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The private object is uploaded by the PutObject (Canned ACL) test.
+	if aclPrivateObject.Key == "" {
+		err := fmt.Errorf("Private ACL object was not created")
+		printMessage(message, err)
+		return false
+	}
+	bucketName := s3verifyACLBucket.Name
+	object := aclPrivateObject
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Make the private object publicly readable through an AccessControlPolicy.
+	expectedGrants := cannedACLGrants(aclOwner, cannedACLs[1])
+	policy := &accessControlPolicy{
+		Owner: aclOwner,
+		AccessControlList: accessControlList{
+			Grants: expectedGrants,
+		},
+	}
+	implemented, err := putObjectACL(config, bucketName, object.Key, "", policy)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if implemented {
+		if err := verifyObjectACL(config, bucketName, object.Key, aclOwner, expectedGrants); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifyAnonymousGetObject(config, bucketName, object, true); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Make the object private again with a canned ACL.
+	if _, err := putObjectACL(config, bucketName, object.Key, cannedACLs[0].name, nil); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectACL(config, bucketName, object.Key, aclOwner, cannedACLGrants(aclOwner, cannedACLs[0])); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyAnonymousGetObject(config, bucketName, object, false); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,107 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// The object uploaded with the private canned ACL, set by the PutObject (Canned ACL) test.
+var aclPrivateObject = &ObjectInfo{}
+
+// newPutObjectCannedACLReq - Create a new HTTP request for PUT object with a canned ACL.
+func newPutObjectCannedACLReq(bucketName, objectName string, objectData []byte, acl string) (Request, error) {
+	putObjectCannedACLReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	putObjectCannedACLReq.customHeader.Set(aclHeader, acl)
+
+	return putObjectCannedACLReq, nil
+}
+
+// mainPutObjectCannedACL - Entry point for the PutObject test with canned ACLs.
+func mainPutObjectCannedACL(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Canned ACL):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyACLBucket.Name
+	aclOwner, err := getCanonicalOwner(config)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	for _, acl := range cannedACLs {
+		// Spin scanBar
+		scanBar(message)
+		object := &ObjectInfo{
+			Key:  "s3verify/acl/" + acl.name,
+			Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+		}
+		// Create a new request.
+		req, err := newPutObjectCannedACLReq(bucketName, object.Key, object.Body, acl.name)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		notImplemented, err := isACLNotImplemented(res)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if notImplemented {
+			continue
+		}
+		// Verify the response.
+		if err := putObjectVerify(res, http.StatusOK); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Save the object so it is removed later.
+		aclObjects = append(aclObjects, object)
+		if acl.name == cannedACLs[0].name {
+			aclPrivateObject = object
+		}
+		// Spin scanBar
+		scanBar(message)
+		// The ACL must have been applied when the object was uploaded.
+		if err := verifyObjectACL(config, bucketName, object.Key, aclOwner, cannedACLGrants(aclOwner, acl)); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Only publicly readable objects can be read without signing the request.
+		if err := verifyAnonymousGetObject(config, bucketName, object, isPublicRead(acl)); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
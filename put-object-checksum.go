@@ -0,0 +1,167 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Holds all objects created by the checksum tests.
+var checksumObjects = []*ObjectInfo{}
+
+// newPutObjectChecksumReq - Create a new HTTP request for PUT object with an additional checksum header.
+func newPutObjectChecksumReq(bucketName, objectName string, objectData []byte, a checksumAlgorithm, checksum string) (Request, error) {
+	putObjectChecksumReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	putObjectChecksumReq.customHeader.Set(a.header(), checksum)
+	putObjectChecksumReq.customHeader.Set(sdkChecksumAlgorithmHeader, a.name)
+	return putObjectChecksumReq, nil
+}
+
+// putObjectChecksum - Upload an object with the given checksum either as a header or as a trailer
+// and verify the response. A non empty expectedCode means the upload must fail.
+func putObjectChecksum(config ServerConfig, bucketName string, object *ObjectInfo, a checksumAlgorithm, checksum string, trailing bool, expectedStatusCode int, expectedCode string) error {
+	req, err := newPutObjectChecksumReq(bucketName, object.Key, object.Body, a, checksum)
+	if err != nil {
+		return err
+	}
+	if trailing {
+		setTrailingChecksum(&req, object.Body, a, checksum)
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if expectedCode != "" {
+		return verifyChecksumErrorResponse(res, expectedStatusCode, expectedCode)
+	}
+	if err := putObjectVerify(res, expectedStatusCode); err != nil {
+		return err
+	}
+	return verifyChecksumHeader(res.Header, a, checksum)
+}
+
+// getObjectChecksumMode - GET or HEAD an object with x-amz-checksum-mode enabled and verify the checksum returned.
+func getObjectChecksumMode(config ServerConfig, method, bucketName string, object *ObjectInfo, a checksumAlgorithm, expectedChecksum string) error {
+	var req Request
+	var err error
+	if method == "HEAD" {
+		req, err = newHeadObjectReq(bucketName, object.Key)
+	} else {
+		req, err = newGetObjectReq(bucketName, object.Key, nil)
+	}
+	if err != nil {
+		return err
+	}
+	req.customHeader.Set(checksumModeHeader, "ENABLED")
+	// Execute the request.
+	res, err := config.execRequest(method, req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if method == "HEAD" {
+		if err := headObjectVerify(res, http.StatusOK); err != nil {
+			return err
+		}
+	} else {
+		if err := getObjectVerify(res, object.Body, http.StatusOK, nil); err != nil {
+			return err
+		}
+	}
+	return verifyChecksumHeader(res.Header, a, expectedChecksum)
+}
+
+// getObjectAttributesChecksum - Verify the checksum listed by GetObjectAttributes.
+func getObjectAttributesChecksum(config ServerConfig, bucketName string, object *ObjectInfo, a checksumAlgorithm, expectedChecksum string) error {
+	attributes, err := getObjectAttributes(config, bucketName, object.Key, []string{"Checksum"})
+	if err != nil {
+		return err
+	}
+	if checksum := attributes.Checksum.get(a); checksum != expectedChecksum {
+		err := fmt.Errorf("Unexpected Checksum%s Received: wanted %v, got %v", a.name, expectedChecksum, checksum)
+		return err
+	}
+	return nil
+}
+
+// mainPutObjectChecksum - Entry point for the PutObject additional checksum test.
+func mainPutObjectChecksum(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Checksums):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	for _, a := range checksumAlgorithms {
+		for _, trailing := range []bool{false, true} {
+			// Spin scanBar
+			scanBar(message)
+			object := &ObjectInfo{
+				Key:  "s3verify/checksum/header/" + a.name,
+				Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+			}
+			if trailing {
+				object.Key = "s3verify/checksum/trailer/" + a.name
+			}
+			checksum := a.checksum(object.Body)
+			// Save the object so it is removed later.
+			checksumObjects = append(checksumObjects, object)
+			if err := putObjectChecksum(config, bucketName, object, a, checksum, trailing, http.StatusOK, ""); err != nil {
+				err := fmt.Errorf("PUT %s: %v", object.Key, err)
+				printMessage(message, err)
+				return false
+			}
+			// The stored checksum is only returned when asked for.
+			for _, method := range []string{"HEAD", "GET"} {
+				if err := getObjectChecksumMode(config, method, bucketName, object, a, checksum); err != nil {
+					err := fmt.Errorf("%s %s: %v", method, object.Key, err)
+					printMessage(message, err)
+					return false
+				}
+			}
+			if err := getObjectAttributesChecksum(config, bucketName, object, a, checksum); err != nil {
+				err := fmt.Errorf("GetObjectAttributes %s: %v", object.Key, err)
+				printMessage(message, err)
+				return false
+			}
+			// A well formed checksum that does not match the data must be rejected.
+			mismatched := &ObjectInfo{
+				Key:  object.Key + "/mismatch",
+				Body: object.Body,
+			}
+			checksumObjects = append(checksumObjects, mismatched)
+			if err := putObjectChecksum(config, bucketName, mismatched, a, a.checksum([]byte("s3verify")), trailing, http.StatusBadRequest, "BadDigest"); err != nil {
+				err := fmt.Errorf("PUT %s: %v", mismatched.Key, err)
+				printMessage(message, err)
+				return false
+			}
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
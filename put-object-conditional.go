@@ -0,0 +1,230 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Number of writers racing to create the same object.
+const numConditionalWriters = 10
+
+// Holds all objects created by the conditional write tests.
+var conditionalObjects = []*ObjectInfo{}
+
+// newPutObjectConditionalReq - Create a new HTTP request for PUT object with a precondition header.
+func newPutObjectConditionalReq(bucketName, objectName string, objectData []byte, header, value string) (Request, error) {
+	putObjectConditionalReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	putObjectConditionalReq.customHeader.Set(header, value)
+	return putObjectConditionalReq, nil
+}
+
+// verifyConditionalErrorResponse - Verify that a conditional write failed with the expected status and error code.
+func verifyConditionalErrorResponse(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// putObjectConditional - Upload an object with a precondition header and return the ETag of the new version.
+// A non empty expectedError code means the precondition must not be met.
+func putObjectConditional(config ServerConfig, bucketName string, object *ObjectInfo, header, value string, expectedStatusCode int, expectedError ErrorResponse) (string, error) {
+	req, err := newPutObjectConditionalReq(bucketName, object.Key, object.Body, header, value)
+	if err != nil {
+		return "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if expectedError.Code != "" {
+		return "", verifyConditionalErrorResponse(res, expectedStatusCode, expectedError)
+	}
+	if err := putObjectVerify(res, expectedStatusCode); err != nil {
+		return "", err
+	}
+	return res.Header.Get("ETag"), nil
+}
+
+// mainPutObjectConditional - Entry point for the PutObject If-None-Match and If-Match test.
+func mainPutObjectConditional(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Conditional Writes):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objectName := "s3verify/conditional/put"
+	versions := []*ObjectInfo{}
+	for i := 0; i < 3; i++ {
+		versions = append(versions, &ObjectInfo{
+			Key:  objectName,
+			Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()+int64(i)), "")),
+		})
+	}
+	// Save the object so it is removed later.
+	conditionalObjects = append(conditionalObjects, versions[0])
+	preconditionFailed := ErrorResponse{Code: "PreconditionFailed"}
+	// Spin scanBar
+	scanBar(message)
+	// Compare-and-swap on an object that does not exist yet can never succeed.
+	if _, err := putObjectConditional(config, bucketName, versions[0], "If-Match", "\"1234567890\"", http.StatusNotFound, ErrorResponse{Code: "NoSuchKey"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Create-only succeeds while the key does not exist.
+	firstETag, err := putObjectConditional(config, bucketName, versions[0], "If-None-Match", "*", http.StatusOK, ErrorResponse{})
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Create-only must not overwrite the existing object.
+	if _, err := putObjectConditional(config, bucketName, versions[1], "If-None-Match", "*", http.StatusPreconditionFailed, preconditionFailed); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Compare-and-swap with an ETag that was never current must fail.
+	if _, err := putObjectConditional(config, bucketName, versions[1], "If-Match", "\"1234567890\"", http.StatusPreconditionFailed, preconditionFailed); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectBody(config, bucketName, versions[0]); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Compare-and-swap with the current ETag replaces the object.
+	if _, err := putObjectConditional(config, bucketName, versions[1], "If-Match", firstETag, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// The ETag read before the swap is now stale.
+	if _, err := putObjectConditional(config, bucketName, versions[2], "If-Match", firstETag, http.StatusPreconditionFailed, preconditionFailed); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyObjectBody(config, bucketName, versions[1]); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
+
+// mainPutObjectConditionalRace - Entry point for the test where concurrent writers race to create the same object.
+func mainPutObjectConditionalRace(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Conditional Write Race):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objectName := "s3verify/conditional/race"
+	writers := make([]*ObjectInfo, numConditionalWriters)
+	for i := range writers {
+		writers[i] = &ObjectInfo{
+			Key:  objectName,
+			Body: []byte("s3verify-writer-" + strconv.Itoa(i)),
+		}
+	}
+	// Save the object so it is removed later.
+	conditionalObjects = append(conditionalObjects, writers[0])
+	statusCodes := make([]int, numConditionalWriters)
+	errs := make([]error, numConditionalWriters)
+	var wg sync.WaitGroup
+	for i, writer := range writers {
+		wg.Add(1)
+		go func(i int, writer *ObjectInfo) {
+			defer wg.Done()
+			req, err := newPutObjectConditionalReq(bucketName, writer.Key, writer.Body, "If-None-Match", "*")
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			res, err := config.execRequest("PUT", req)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer closeResponse(res)
+			statusCodes[i] = res.StatusCode
+		}(i, writer)
+	}
+	wg.Wait()
+	// Spin scanBar
+	scanBar(message)
+	winner := -1
+	for i, statusCode := range statusCodes {
+		if errs[i] != nil {
+			printMessage(message, errs[i])
+			return false
+		}
+		switch statusCode {
+		case http.StatusOK:
+			if winner != -1 {
+				err := fmt.Errorf("Unexpected Conditional Write Result: writers %d and %d both created %s", winner, i, objectName)
+				printMessage(message, err)
+				return false
+			}
+			winner = i
+		// Writers that lose the race either see the winner's object or a conflicting in-flight write.
+		case http.StatusPreconditionFailed, http.StatusConflict:
+		default:
+			err := fmt.Errorf("Unexpected Status Received for writer %d: wanted %v or %v, got %v", i, http.StatusOK, http.StatusPreconditionFailed, statusCode)
+			printMessage(message, err)
+			return false
+		}
+	}
+	if winner == -1 {
+		err := fmt.Errorf("Unexpected Conditional Write Result: none of the %d writers created %s", numConditionalWriters, objectName)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The stored object must be the one written by the winner.
+	if err := verifyObjectBody(config, bucketName, writers[winner]); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,103 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// mainPutObjectDefaultEncryption - Entry point for the test of objects inheriting the default encryption of their bucket.
+func mainPutObjectDefaultEncryption(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Default Encryption):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Default encryption is only configured on the second s3verify created bucket.
+	bucketName := s3verifyBuckets[1].Name
+	defaultEncryptionObjects := []*ObjectInfo{
+		&ObjectInfo{
+			Key:                  "s3verify/sse/default-s3",
+			ServerSideEncryption: sseAlgorithmAES256,
+		},
+		&ObjectInfo{
+			Key:                  "s3verify/sse/default-kms",
+			ServerSideEncryption: sseAlgorithmKMS,
+			KMSKeyID:             config.KMSKeyID,
+		},
+	}
+	for _, object := range defaultEncryptionObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Make the encryption of the object the default of the bucket.
+		if err := putBucketEncryption(config, bucketName, newBucketEncryption(object.ServerSideEncryption, object.KMSKeyID)); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+		// Upload the object without any SSE headers.
+		object.Body = []byte(randString(60, rand.NewSource(time.Now().UnixNano()), ""))
+		req, err := newPutObjectReq(bucketName, object.Key, object.Body)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response reports the default encryption.
+		if err := putObjectSSEVerify(res, http.StatusOK, object, ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Save the object so it is removed later.
+		sseObjects = append(sseObjects, object)
+		// Spin scanBar
+		scanBar(message)
+		// The stored object must report the default encryption as well.
+		headReq, err := newHeadObjectReq(bucketName, object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		headRes, err := config.execRequest("HEAD", headReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(headRes)
+		// Verify the response.
+		if err := headObjectSSEVerify(headRes, http.StatusOK, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
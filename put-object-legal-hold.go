@@ -0,0 +1,180 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// The object version placed under legal hold, set by the PutObjectLegalHold test.
+var legalHoldObject = &ObjectInfo{}
+
+// newPutObjectLegalHoldReq - Create a new HTTP request for the PutObjectLegalHold API.
+func newPutObjectLegalHoldReq(bucketName, objectName, versionID, status string) (Request, error) {
+	// putObjectLegalHoldReq - a new HTTP request for the PutObjectLegalHold API.
+	var putObjectLegalHoldReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	putObjectLegalHoldReq.bucketName = bucketName
+	putObjectLegalHoldReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("legal-hold", "")
+	urlValues.Set("versionId", versionID)
+	putObjectLegalHoldReq.queryValues = urlValues
+
+	legalHoldBytes, err := xml.Marshal(objectLegalHold{Status: status})
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(legalHoldBytes)
+	// Content-MD5 is required for PutObjectLegalHold requests.
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putObjectLegalHoldReq.contentBody = reader
+	putObjectLegalHoldReq.contentLength = contentLength
+	putObjectLegalHoldReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putObjectLegalHoldReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putObjectLegalHoldReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putObjectLegalHoldReq, nil
+}
+
+// putObjectLegalHoldVerify - Verify that the response returned matches what is expected.
+func putObjectLegalHoldVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutObjectLegalHold(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectLegalHold(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectLegalHold(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutObjectLegalHold - Verify that the status returned matches what is expected.
+func verifyStatusPutObjectLegalHold(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectLegalHold - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectLegalHold(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutObjectLegalHold - Verify that the body returned is empty.
+func verifyBodyPutObjectLegalHold(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// putObjectLegalHold - Set the legal hold of an object version.
+func putObjectLegalHold(config ServerConfig, object *ObjectInfo, status string) error {
+	req, err := newPutObjectLegalHoldReq(s3verifyObjectLockBucket.Name, object.Key, object.VersionID, status)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return putObjectLegalHoldVerify(res, http.StatusOK)
+}
+
+// mainPutObjectLegalHold - Entry point for the PutObjectLegalHold API test.
+func mainPutObjectLegalHold(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObjectLegalHold:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	object, err := putObjectLockObject(config, "s3verify/lock/legal-hold")
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Place the object version under legal hold.
+	if err := putObjectLegalHold(config, object, legalHoldOn); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	legalHoldObject = object
+	// Spin scanBar
+	scanBar(message)
+	// The legal hold must be reported in the headers of the object.
+	headReq, err := newHeadObjectReq(s3verifyObjectLockBucket.Name, object.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	headRes, err := config.execRequest("HEAD", headReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(headRes)
+	// Verify the response.
+	if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if status := headRes.Header.Get(objectLockLegalHoldHeader); status != legalHoldOn {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", objectLockLegalHoldHeader, legalHoldOn, status)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
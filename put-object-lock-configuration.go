@@ -0,0 +1,187 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// The object lock configuration applied to the object lock enabled bucket.
+var s3verifyObjectLockConfig = objectLockConfiguration{
+	ObjectLockEnabled: "Enabled",
+	Rule: &objectLockRule{
+		// New objects are retained in governance mode for a day by default.
+		DefaultRetention: objectLockDefaultRetention{
+			Mode: objectLockModeGovernance,
+			Days: 1,
+		},
+	},
+}
+
+// newPutObjectLockConfigurationReq - Create a new HTTP request for the PutObjectLockConfiguration API.
+func newPutObjectLockConfigurationReq(bucketName string, lockConfig objectLockConfiguration) (Request, error) {
+	// putObjectLockConfigurationReq - a new HTTP request for the PutObjectLockConfiguration API.
+	var putObjectLockConfigurationReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putObjectLockConfigurationReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("object-lock", "")
+	putObjectLockConfigurationReq.queryValues = urlValues
+
+	lockConfigBytes, err := xml.Marshal(lockConfig)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(lockConfigBytes)
+	// Content-MD5 is required for PutObjectLockConfiguration requests.
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putObjectLockConfigurationReq.contentBody = reader
+	putObjectLockConfigurationReq.contentLength = contentLength
+	putObjectLockConfigurationReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putObjectLockConfigurationReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putObjectLockConfigurationReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putObjectLockConfigurationReq, nil
+}
+
+// putObjectLockConfigurationVerify - Verify that the response returned matches what is expected.
+func putObjectLockConfigurationVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusPutObjectLockConfiguration(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectLockConfiguration(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectLockConfiguration(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutObjectLockConfiguration - Verify that the status returned matches what is expected.
+func verifyStatusPutObjectLockConfiguration(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectLockConfiguration - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectLockConfiguration(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutObjectLockConfiguration - Verify that the body returned is empty.
+func verifyBodyPutObjectLockConfiguration(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutObjectLockConfiguration - Entry point for the PutObjectLockConfiguration API test.
+func mainPutObjectLockConfiguration(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObjectLockConfiguration:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyObjectLockBucket.Name
+	// Create a new request.
+	req, err := newPutObjectLockConfigurationReq(bucketName, s3verifyObjectLockConfig)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := putObjectLockConfigurationVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Objects uploaded without a retention of their own must inherit the default retention.
+	object, err := putObjectLockObject(config, "s3verify/lock/default")
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	headReq, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	headRes, err := config.execRequest("HEAD", headReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(headRes)
+	// Verify the response.
+	if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// The retention is computed from the upload time so only its mode and whether it is in effect can be checked.
+	if err := verifyObjectLockHeaders(headRes.Header, objectLockModeGovernance, time.Time{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,319 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Prefix of every user metadata header.
+const userMetadataPrefix = "X-Amz-Meta-"
+
+// Largest size in bytes of all user metadata keys and values on an object.
+const maxUserMetadataSize = 2 * 1024
+
+// System headers that are stored with an object and returned on HEAD and GET.
+var systemMetadataHeaders = []string{
+	"Cache-Control",
+	"Content-Disposition",
+	"Content-Encoding",
+	"Content-Language",
+	"Content-Type",
+	"Expires",
+}
+
+// Holds all objects created by the metadata tests.
+var metadataObjects = []*ObjectInfo{}
+
+// newMetadataObjects - Objects carrying user metadata, system headers and metadata close to the size limit.
+func newMetadataObjects() []*ObjectInfo {
+	return []*ObjectInfo{
+		&ObjectInfo{
+			Key: "s3verify/metadata/user",
+			Metadata: http.Header{
+				// Sent as written, S3 stores user metadata keys in lowercase and keeps values as they are.
+				"X-Amz-Meta-MixedCaseKey": []string{"MixedCaseValue"},
+				"X-Amz-Meta-Spaces":       []string{"a value with  spaces"},
+				// Non ASCII values must be sent encoded as RFC 2047 words.
+				"X-Amz-Meta-Unicode": []string{mime.BEncoding.Encode("UTF-8", "ünïcödé-日本語")},
+			},
+		},
+		&ObjectInfo{
+			Key: "s3verify/metadata/system",
+			Metadata: http.Header{
+				"X-Amz-Meta-Source":   []string{"s3verify"},
+				"Cache-Control":       []string{"max-age=3600, must-revalidate"},
+				"Content-Disposition": []string{`attachment; filename="s3verify.txt"`},
+				// Any encoding other than gzip so the response is not decompressed by the client.
+				"Content-Encoding": []string{"identity"},
+				"Content-Language": []string{"en-US"},
+				"Content-Type":     []string{"text/plain; charset=utf-8"},
+				"Expires":          []string{time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat)},
+			},
+		},
+		&ObjectInfo{
+			Key: "s3verify/metadata/large",
+			Metadata: http.Header{
+				// Stay under the limit whether or not the x-amz-meta- prefix is counted.
+				"X-Amz-Meta-Large": []string{strings.Repeat("l", maxUserMetadataSize-len("X-Amz-Meta-Large")-64)},
+			},
+		},
+	}
+}
+
+// newPutObjectMetadataReq - Create a new HTTP request for PUT object with metadata headers.
+func newPutObjectMetadataReq(bucketName string, object *ObjectInfo) (Request, error) {
+	putObjectMetadataReq, err := newPutObjectReq(bucketName, object.Key, object.Body)
+	if err != nil {
+		return Request{}, err
+	}
+	for k, v := range object.Metadata {
+		// Keys are not canonicalized so mixed case metadata keys are sent as written.
+		putObjectMetadataReq.customHeader[k] = []string{v[0]}
+	}
+	return putObjectMetadataReq, nil
+}
+
+// decodeMetadataValue - Decode a value that may have been returned as RFC 2047 words.
+func decodeMetadataValue(value string) string {
+	decoder := new(mime.WordDecoder)
+	decoded, err := decoder.DecodeHeader(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
+
+// verifyObjectMetadata - Verify that exactly the expected user metadata and system headers were returned.
+func verifyObjectMetadata(header http.Header, expected http.Header) error {
+	// User metadata keys come back lowercased, which net/http reports under the canonical form of the key,
+	// so the keys sent in any case are looked up in their lowercased canonical form.
+	lowercased := http.Header{}
+	for k, v := range expected {
+		lowercased[http.CanonicalHeaderKey(strings.ToLower(k))] = v
+	}
+	expected = lowercased
+	for k := range header {
+		if strings.HasPrefix(k, userMetadataPrefix) && expected.Get(k) == "" {
+			err := fmt.Errorf("Unexpected Metadata Received: %s: %s", k, header.Get(k))
+			return err
+		}
+	}
+	for k := range expected {
+		if !strings.HasPrefix(k, userMetadataPrefix) {
+			continue
+		}
+		if value, expectedValue := decodeMetadataValue(header.Get(k)), decodeMetadataValue(expected.Get(k)); value != expectedValue {
+			err := fmt.Errorf("Unexpected Metadata Received for %s: wanted %q, got %q", k, expectedValue, value)
+			return err
+		}
+	}
+	for _, k := range systemMetadataHeaders {
+		// Servers choose their own Content-Type when none was sent.
+		if k == "Content-Type" && expected.Get(k) == "" {
+			continue
+		}
+		if header.Get(k) != expected.Get(k) {
+			err := fmt.Errorf("Unexpected Header Value Received for %s: wanted %q, got %q", k, expected.Get(k), header.Get(k))
+			return err
+		}
+	}
+	return nil
+}
+
+// putObjectMetadata - Upload an object with its metadata and verify the status returned.
+func putObjectMetadata(config ServerConfig, bucketName string, object *ObjectInfo, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutObjectMetadataReq(bucketName, object)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if expectedError.Code == "" {
+		return putObjectVerify(res, expectedStatusCode)
+	}
+	if err := verifyStatusPutObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// headObjectMetadata - HEAD an object and verify the metadata returned.
+func headObjectMetadata(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		return err
+	}
+	return verifyObjectMetadata(res.Header, object.Metadata)
+}
+
+// getObjectMetadata - GET an object and verify the metadata and body returned.
+func getObjectMetadata(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newGetObjectReq(bucketName, object.Key, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyObjectMetadata(res.Header, object.Metadata); err != nil {
+		return err
+	}
+	return getObjectVerify(res, object.Body, http.StatusOK, nil)
+}
+
+// listObjectsMetadata - List objects with metadata=true and verify any user metadata listed.
+// Listing metadata is an extension to S3 so servers that do not list it are not failed.
+func listObjectsMetadata(config ServerConfig, bucketName string, objects []*ObjectInfo) error {
+	req, err := newListObjectsV2Req(bucketName, map[string]string{
+		"prefix":   "s3verify/metadata/",
+		"metadata": "true",
+	})
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyStatusListObjectsV2(res.StatusCode, http.StatusOK); err != nil {
+		return err
+	}
+	result := listObjectsMetadataResult{}
+	if err := xmlDecoder(res.Body, &result); err != nil {
+		return err
+	}
+	if len(result.Contents) != len(objects) {
+		err := fmt.Errorf("Unexpected Number of Objects Listed: wanted %d, got %d", len(objects), len(result.Contents))
+		return err
+	}
+	for _, listed := range result.Contents {
+		if listed.UserMetadata == nil {
+			continue
+		}
+		for _, object := range objects {
+			if object.Key != listed.Key {
+				continue
+			}
+			header := http.Header{}
+			for k, v := range listed.UserMetadata {
+				header.Set(k, v)
+			}
+			for k := range object.Metadata {
+				if !strings.HasPrefix(k, userMetadataPrefix) {
+					continue
+				}
+				if value, expectedValue := decodeMetadataValue(header.Get(k)), decodeMetadataValue(object.Metadata[k][0]); value != expectedValue {
+					err := fmt.Errorf("Unexpected Metadata Listed for %s %s: wanted %q, got %q", object.Key, k, expectedValue, value)
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// mainPutObjectMetadata - Entry point for the user metadata and system header round-trip test.
+func mainPutObjectMetadata(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Metadata):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objects := newMetadataObjects()
+	for _, object := range objects {
+		// Spin scanBar
+		scanBar(message)
+		object.Body = []byte(randString(60, rand.NewSource(time.Now().UnixNano()), ""))
+		// Save the object so it is removed later.
+		metadataObjects = append(metadataObjects, object)
+		if err := putObjectMetadata(config, bucketName, object, http.StatusOK, ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := headObjectMetadata(config, bucketName, object); err != nil {
+			err := fmt.Errorf("HEAD %s: %v", object.Key, err)
+			printMessage(message, err)
+			return false
+		}
+		if err := getObjectMetadata(config, bucketName, object); err != nil {
+			err := fmt.Errorf("GET %s: %v", object.Key, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := listObjectsMetadata(config, bucketName, objects); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// User metadata larger than 2KB must be rejected.
+	tooLarge := &ObjectInfo{
+		Key:  "s3verify/metadata/too-large",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+		Metadata: http.Header{
+			"X-Amz-Meta-Large": []string{strings.Repeat("l", maxUserMetadataSize+1)},
+		},
+	}
+	metadataObjects = append(metadataObjects, tooLarge)
+	if err := putObjectMetadata(config, bucketName, tooLarge, http.StatusBadRequest, ErrorResponse{Code: "MetadataTooLarge"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
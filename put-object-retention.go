@@ -0,0 +1,244 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// retainedObject - An object version along with the retention placed on it.
+type retainedObject struct {
+	object    *ObjectInfo
+	retention objectRetention
+	period    time.Duration // How long the object version is retained for.
+	applied   bool          // Whether the retention was placed on the object version.
+}
+
+// The object versions retained by the PutObjectRetention test.
+var retainedObjects = []*retainedObject{
+	// Governance retention can be bypassed.
+	&retainedObject{
+		object: &ObjectInfo{
+			Key: "s3verify/lock/governance",
+		},
+		retention: objectRetention{
+			Mode: objectLockModeGovernance,
+		},
+		period: 24 * time.Hour,
+	},
+	// Compliance retention cannot be bypassed.
+	&retainedObject{
+		object: &ObjectInfo{
+			Key: "s3verify/lock/compliance",
+		},
+		retention: objectRetention{
+			Mode: objectLockModeCompliance,
+		},
+		period: complianceRetentionPeriod,
+	},
+}
+
+// newPutObjectRetentionReq - Create a new HTTP request for the PutObjectRetention API.
+func newPutObjectRetentionReq(bucketName, objectName, versionID string, retention objectRetention) (Request, error) {
+	// putObjectRetentionReq - a new HTTP request for the PutObjectRetention API.
+	var putObjectRetentionReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	putObjectRetentionReq.bucketName = bucketName
+	putObjectRetentionReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("retention", "")
+	urlValues.Set("versionId", versionID)
+	putObjectRetentionReq.queryValues = urlValues
+
+	retentionBytes, err := xml.Marshal(retention)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(retentionBytes)
+	// Content-MD5 is required for PutObjectRetention requests.
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putObjectRetentionReq.contentBody = reader
+	putObjectRetentionReq.contentLength = contentLength
+	putObjectRetentionReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putObjectRetentionReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putObjectRetentionReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putObjectRetentionReq, nil
+}
+
+// putObjectRetentionVerify - Verify that the response returned matches what is expected.
+func putObjectRetentionVerify(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) error {
+	if err := verifyStatusPutObjectRetention(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectRetention(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectRetention(res.Body, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutObjectRetention - Verify that the status returned matches what is expected.
+func verifyStatusPutObjectRetention(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectRetention - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectRetention(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyPutObjectRetention - Verify that the body returned is empty or the expected error.
+func verifyBodyPutObjectRetention(resBody io.Reader, expectedError ErrorResponse) error {
+	if expectedError.Code != "" {
+		return verifyObjectLockErrorResponse(resBody, expectedError)
+	}
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutObjectRetention - Entry point for the PutObjectRetention API test.
+func mainPutObjectRetention(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObjectRetention:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyObjectLockBucket.Name
+	for _, retained := range retainedObjects {
+		// Spin scanBar
+		scanBar(message)
+		object, err := putObjectLockObject(config, retained.object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		retained.object = object
+		retained.retention.RetainUntilDate = newObjectLockRetainUntil(retained.period)
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newPutObjectRetentionReq(bucketName, object.Key, object.VersionID, retained.retention)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := putObjectRetentionVerify(res, http.StatusOK, ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		retained.applied = true
+		// Spin scanBar
+		scanBar(message)
+		// The retention must be reported in the headers of the object.
+		headReq, err := newHeadObjectReq(bucketName, object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		headRes, err := config.execRequest("HEAD", headReq)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(headRes)
+		// Verify the response.
+		if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if err := verifyObjectLockHeaders(headRes.Header, retained.retention.Mode, retained.retention.RetainUntilDate); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Compliance retention cannot be shortened, not even by the owner.
+	compliance := retainedObjects[1]
+	shortened := objectRetention{
+		Mode:            compliance.retention.Mode,
+		RetainUntilDate: compliance.retention.RetainUntilDate.Add(-compliance.period / 2),
+	}
+	expectedError := ErrorResponse{
+		Code: "AccessDenied",
+	}
+	shortenReq, err := newPutObjectRetentionReq(bucketName, compliance.object.Key, compliance.object.VersionID, shortened)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	shortenRes, err := config.execRequest("PUT", shortenReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(shortenRes)
+	// Verify the request failed as expected.
+	if err := putObjectRetentionVerify(shortenRes, http.StatusForbidden, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
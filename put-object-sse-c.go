@@ -0,0 +1,155 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Store all objects that are uploaded encrypted with a customer provided key.
+var sseCustomerObjects = []*ObjectInfo{}
+
+// The customer provided key all sseCustomerObjects are encrypted with.
+var s3verifySSECustomerKey []byte
+
+// newPutObjectSSECReq - Create a new HTTP request for PUT object encrypted with a customer provided key.
+func newPutObjectSSECReq(bucketName, objectName string, objectData, key []byte) (Request, error) {
+	// An SSE-C PUT is a regular PUT object with the SSE-C headers set.
+	putObjectSSECReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSECustomerHeaders(putObjectSSECReq.customHeader, key)
+
+	return putObjectSSECReq, nil
+}
+
+// putObjectSSECVerify - Verify that the response returned matches what is expected.
+func putObjectSSECVerify(res *http.Response, expectedStatusCode int, key []byte, expectedError ErrorResponse) error {
+	if err := verifyStatusPutObjectSSEC(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectSSEC(res.Header, key, expectedError); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectSSEC(res.Body, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusPutObjectSSEC - Verify that the status returned matches what is expected.
+func verifyStatusPutObjectSSEC(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectSSEC - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectSSEC(header http.Header, key []byte, expectedError ErrorResponse) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if expectedError.Code == "" {
+		if err := verifySSECustomerHeaders(header, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBodyPutObjectSSEC - Verify that the body returned matches what is expected.
+func verifyBodyPutObjectSSEC(resBody io.Reader, expectedError ErrorResponse) error {
+	if expectedError.Code != "" {
+		return verifySSEErrorResponse(resBody, expectedError)
+	}
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	// A PUT request should give back an empty body.
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: expected empty body but received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainPutObjectSSEC - Entry point for the PutObject test with a customer provided key.
+func mainPutObjectSSEC(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (SSE-C):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// SSE-C objects are only uploaded to s3verify created buckets.
+	bucketName := s3verifyBuckets[0].Name
+	key, err := newSSECustomerKey()
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	object := &ObjectInfo{
+		Key:  "s3verify/sse-c/object",
+		Body: []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	// Customer provided keys must only ever be sent over TLS.
+	expectedStatusCode := http.StatusOK
+	expectedError := ErrorResponse{}
+	if !isSecureEndpoint(config.Endpoint) {
+		expectedStatusCode = http.StatusBadRequest
+		expectedError = errSSECustomerInsecure
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Create a new request.
+	req, err := newPutObjectSSECReq(bucketName, object.Key, object.Body, key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := putObjectSSECVerify(res, expectedStatusCode, key, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if expectedError.Code == "" {
+		// Store the object and its key for the remaining SSE-C tests.
+		s3verifySSECustomerKey = key
+		sseCustomerObjects = append(sseCustomerObjects, object)
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,157 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Holds all objects uploaded with SSE-S3 or SSE-KMS.
+var sseObjects = []*ObjectInfo{
+	// An object encrypted with S3 managed keys.
+	&ObjectInfo{
+		Key:                  "s3verify/sse/s3",
+		ServerSideEncryption: sseAlgorithmAES256,
+		// Body: to be set dynamically,
+	},
+	// An object encrypted with a KMS managed key.
+	&ObjectInfo{
+		Key:                  "s3verify/sse/kms",
+		ServerSideEncryption: sseAlgorithmKMS,
+		// Body: to be set dynamically,
+		// KMSKeyID: to be set dynamically,
+	},
+}
+
+// newPutObjectSSEReq - Create a new HTTP request for PUT object with SSE-S3 or SSE-KMS.
+func newPutObjectSSEReq(bucketName, objectName string, objectData []byte, algorithm, kmsKeyID string) (Request, error) {
+	// An SSE PUT is a regular PUT object with the SSE headers set.
+	putObjectSSEReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	setSSEHeaders(putObjectSSEReq.customHeader, algorithm, kmsKeyID)
+
+	return putObjectSSEReq, nil
+}
+
+// putObjectSSEVerify - Verify that the response returned matches what is expected.
+func putObjectSSEVerify(res *http.Response, expectedStatusCode int, object *ObjectInfo, expectedError ErrorResponse) error {
+	if err := verifyStatusPutObject(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderPutObjectSSE(res.Header, object, expectedError); err != nil {
+		return err
+	}
+	if err := verifyBodyPutObjectSSE(res.Body, expectedError); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderPutObjectSSE - Verify that the header returned matches what is expected.
+func verifyHeaderPutObjectSSE(header http.Header, object *ObjectInfo, expectedError ErrorResponse) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	if expectedError.Code == "" {
+		if err := verifySSEHeaders(header, object.ServerSideEncryption, object.KMSKeyID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyBodyPutObjectSSE - Verify that the body returned matches what is expected.
+func verifyBodyPutObjectSSE(resBody io.Reader, expectedError ErrorResponse) error {
+	if expectedError.Code != "" {
+		return verifySSEErrorResponse(resBody, expectedError)
+	}
+	return verifyBodyPutObject(resBody)
+}
+
+// mainPutObjectSSE - Entry point for the PutObject test with SSE-S3 and SSE-KMS.
+func mainPutObjectSSE(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (SSE-S3/SSE-KMS):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// SSE objects are only uploaded to s3verify created buckets.
+	bucketName := s3verifyBuckets[0].Name
+	for _, object := range sseObjects {
+		// Spin scanBar
+		scanBar(message)
+		if object.ServerSideEncryption == sseAlgorithmKMS {
+			object.KMSKeyID = config.KMSKeyID
+		}
+		object.Body = []byte(randString(60, rand.NewSource(time.Now().UnixNano()), ""))
+		// Create a new request.
+		req, err := newPutObjectSSEReq(bucketName, object.Key, object.Body, object.ServerSideEncryption, object.KMSKeyID)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := putObjectSSEVerify(res, http.StatusOK, object, ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+	}
+	// An unsupported encryption algorithm must be refused.
+	invalidObject := &ObjectInfo{
+		Key:                  "s3verify/sse/invalid",
+		ServerSideEncryption: "AES512",
+		Body:                 []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+	}
+	expectedError := ErrorResponse{
+		Code: "InvalidArgument",
+	}
+	invalidReq, err := newPutObjectSSEReq(bucketName, invalidObject.Key, invalidObject.Body, invalidObject.ServerSideEncryption, "")
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	invalidRes, err := config.execRequest("PUT", invalidReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(invalidRes)
+	// Verify the request failed as expected.
+	if err := putObjectSSEVerify(invalidRes, http.StatusBadRequest, invalidObject, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
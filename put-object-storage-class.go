@@ -0,0 +1,251 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Header selecting the storage class of an object.
+const storageClassHeader = "X-Amz-Storage-Class"
+
+// Storage classes.
+const (
+	storageClassStandard          = "STANDARD"
+	storageClassReducedRedundancy = "REDUCED_REDUNDANCY"
+	storageClassStandardIA        = "STANDARD_IA"
+	storageClassGlacier           = "GLACIER"
+)
+
+// The prefix all storage class objects are uploaded under.
+const storageClassPrefix = "s3verify/storage-class/"
+
+var (
+	// Storage classes objects are uploaded with.
+	storageClasses = []string{storageClassStandard, storageClassReducedRedundancy, storageClassStandardIA, storageClassGlacier}
+
+	// Holds all objects uploaded with a storage class.
+	storageClassObjects = []*ObjectInfo{}
+)
+
+// newPutObjectStorageClassReq - Create a new HTTP request for PUT object with a storage class.
+func newPutObjectStorageClassReq(bucketName, objectName string, objectData []byte, storageClass string) (Request, error) {
+	putObjectStorageClassReq, err := newPutObjectReq(bucketName, objectName, objectData)
+	if err != nil {
+		return Request{}, err
+	}
+	putObjectStorageClassReq.customHeader.Set(storageClassHeader, storageClass)
+
+	return putObjectStorageClassReq, nil
+}
+
+// putObjectStorageClass - Upload an object with a storage class and verify the response.
+// A non empty expectedError code means the upload must be rejected.
+func putObjectStorageClass(config ServerConfig, bucketName string, object *ObjectInfo, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newPutObjectStorageClassReq(bucketName, object.Key, object.Body, object.StorageClass)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	if expectedError.Code != "" {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return err
+		}
+		return nil
+	}
+	// Save the object so it is removed later.
+	storageClassObjects = append(storageClassObjects, object)
+	return verifyStandardHeaders(res.Header)
+}
+
+// verifyStorageClass - STANDARD objects may omit their storage class, every other class must be reported.
+func verifyStorageClass(expectedStorageClass, storageClass string) error {
+	if storageClass == "" && expectedStorageClass == storageClassStandard {
+		return nil
+	}
+	if storageClass != expectedStorageClass {
+		err := fmt.Errorf("Unexpected Storage Class Received: wanted %s, got %s", expectedStorageClass, storageClass)
+		return err
+	}
+	return nil
+}
+
+// headObjectStorageClass - Verify that a HEAD on an object reports its storage class.
+func headObjectStorageClass(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newHeadObjectReq(bucketName, object.Key)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		return err
+	}
+	return verifyStorageClass(object.StorageClass, res.Header.Get(storageClassHeader))
+}
+
+// listObjectsStorageClass - Verify that listing the storage class objects reports the class of each.
+func listObjectsStorageClass(config ServerConfig, bucketName string, objects []*ObjectInfo) error {
+	result, err := listObjectsV2Page(config, bucketName, map[string]string{
+		"prefix": storageClassPrefix,
+	})
+	if err != nil {
+		return err
+	}
+	if len(result.Contents) != len(objects) {
+		err := fmt.Errorf("Unexpected Number of Objects Listed: wanted %d, got %d", len(objects), len(result.Contents))
+		return err
+	}
+	for _, object := range objects {
+		found := false
+		for _, content := range result.Contents {
+			if content.Key != object.Key {
+				continue
+			}
+			found = true
+			if err := verifyStorageClass(object.StorageClass, content.StorageClass); err != nil {
+				return fmt.Errorf("%s: %v", object.Key, err)
+			}
+		}
+		if !found {
+			err := fmt.Errorf("Object %s was not listed", object.Key)
+			return err
+		}
+	}
+	return nil
+}
+
+// getArchivedObject - Verify that an archived object can not be read before it is restored.
+func getArchivedObject(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newGetObjectReq(bucketName, object.Key, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != http.StatusForbidden {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusForbidden, res.StatusCode)
+		return err
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != "InvalidObjectState" {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", "InvalidObjectState", receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// mainPutObjectStorageClass - Entry point for the PutObject test with storage classes.
+func mainPutObjectStorageClass(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutObject (Storage Class):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objects := []*ObjectInfo{}
+	for _, storageClass := range storageClasses {
+		// Spin scanBar
+		scanBar(message)
+		object := &ObjectInfo{
+			Key:          storageClassPrefix + storageClass,
+			Body:         []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+			StorageClass: storageClass,
+		}
+		if err := putObjectStorageClass(config, bucketName, object, http.StatusOK, ErrorResponse{}); err != nil {
+			err := fmt.Errorf("%s: %v", storageClass, err)
+			printMessage(message, err)
+			return false
+		}
+		if err := headObjectStorageClass(config, bucketName, object); err != nil {
+			err := fmt.Errorf("%s: %v", storageClass, err)
+			printMessage(message, err)
+			return false
+		}
+		objects = append(objects, object)
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := listObjectsStorageClass(config, bucketName, objects); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Archived objects must be restored before they can be read, objects of other classes are read directly.
+	for _, object := range objects {
+		if object.StorageClass != storageClassGlacier {
+			if err := verifyObjectBody(config, bucketName, object); err != nil {
+				err := fmt.Errorf("%s: %v", object.StorageClass, err)
+				printMessage(message, err)
+				return false
+			}
+			continue
+		}
+		if err := getArchivedObject(config, bucketName, object); err != nil {
+			err := fmt.Errorf("%s: %v", object.StorageClass, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Unknown storage classes are rejected.
+	invalid := &ObjectInfo{
+		Key:          storageClassPrefix + "invalid",
+		Body:         []byte(randString(60, rand.NewSource(time.Now().UnixNano()), "")),
+		StorageClass: "S3VERIFY",
+	}
+	if err := putObjectStorageClass(config, bucketName, invalid, http.StatusBadRequest, ErrorResponse{Code: "InvalidStorageClass"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -62,7 +62,10 @@ func newPutObjectReq(bucketName, objectName string, objectData []byte) (Request,
 
 	putObjectReq.contentLength = contentLength
 	// Set the body to the data held in objectData.
-	putObjectReq.contentBody = reader
+	// Zero byte objects are sent without a body so the request is not sent chunked.
+	if contentLength > 0 {
+		putObjectReq.contentBody = reader
+	}
 
 	return putObjectReq, nil
 }
@@ -112,6 +115,22 @@ func verifyHeaderPutObject(header http.Header) error {
 	return nil
 }
 
+// putObject - Upload an object with no special headers set.
+func putObject(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newPutObjectReq(bucketName, object.Key, object.Body)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return putObjectVerify(res, http.StatusOK)
+}
+
 // TODO: need mainPutObjectPrepared and mainPutObjectUnPrepared.
 func mainPutObjectPrepared(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] PutObject:", curTest, globalTotalNumTest)
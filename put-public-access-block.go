@@ -0,0 +1,121 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// The public access block blocking every kind of public access, applied to the access control bucket.
+var s3verifyPublicAccessBlock = publicAccessBlockConfiguration{
+	BlockPublicAcls:       true,
+	IgnorePublicAcls:      true,
+	BlockPublicPolicy:     true,
+	RestrictPublicBuckets: true,
+}
+
+// newPutPublicAccessBlockReq - Create a new HTTP request for the PutPublicAccessBlock API.
+func newPutPublicAccessBlockReq(bucketName string, publicAccessBlock publicAccessBlockConfiguration) (Request, error) {
+	// putPublicAccessBlockReq - a new HTTP request for the PutPublicAccessBlock API.
+	var putPublicAccessBlockReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	putPublicAccessBlockReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+	putPublicAccessBlockReq.queryValues = urlValues
+
+	publicAccessBlockBytes, err := xml.Marshal(publicAccessBlock)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(publicAccessBlockBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	putPublicAccessBlockReq.contentBody = reader
+	putPublicAccessBlockReq.contentLength = contentLength
+	putPublicAccessBlockReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	putPublicAccessBlockReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	putPublicAccessBlockReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return putPublicAccessBlockReq, nil
+}
+
+// putPublicAccessBlock - Set the public access block of a bucket and verify the response.
+func putPublicAccessBlock(config ServerConfig, bucketName string, publicAccessBlock publicAccessBlockConfiguration) error {
+	req, err := newPutPublicAccessBlockReq(bucketName, publicAccessBlock)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, http.StatusOK, ErrorResponse{}); err != nil {
+		return err
+	}
+	return verifyEmptyBody(res)
+}
+
+// mainPutPublicAccessBlock - Entry point for the PutPublicAccessBlock API test.
+func mainPutPublicAccessBlock(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] PutPublicAccessBlock:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyAccessBucket.Name
+	// Settings are replaced as a whole, so a partial block must be returned without the settings left out.
+	partial := publicAccessBlockConfiguration{
+		BlockPublicAcls: true,
+	}
+	if err := putPublicAccessBlock(config, bucketName, partial); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := getPublicAccessBlock(config, bucketName, partial, http.StatusOK, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Block everything for the tests that follow.
+	if err := putPublicAccessBlock(config, bucketName, s3verifyPublicAccessBlock); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,77 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// mainRemoveBucketAccess - Entry point for removing the access control bucket and every object in it.
+func mainRemoveBucketAccess(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucket (Access Controls):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyAccessBucket.Name
+	for _, object := range accessObjects {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newRemoveObjectReq(config, bucketName, object.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("DELETE", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the response.
+		if err := removeObjectVerify(res, http.StatusNoContent); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Generate the new DELETE bucket request.
+	req, err := newRemoveBucketReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Perform the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := removeBucketVerify(res, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
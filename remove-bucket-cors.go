@@ -0,0 +1,158 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newRemoveBucketCorsReq - Create a new HTTP request for the DeleteBucketCors API.
+func newRemoveBucketCorsReq(bucketName string) (Request, error) {
+	// removeBucketCorsReq - a new HTTP request for the DeleteBucketCors API.
+	var removeBucketCorsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removeBucketCorsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("cors", "")
+	removeBucketCorsReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removeBucketCorsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removeBucketCorsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removeBucketCorsReq, nil
+}
+
+// removeBucketCorsVerify - Verify that the response returned matches what is expected.
+func removeBucketCorsVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusRemoveBucketCors(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderRemoveBucketCors(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyRemoveBucketCors(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusRemoveBucketCors - Verify that the status returned matches what is expected.
+func verifyStatusRemoveBucketCors(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %d, got %d", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderRemoveBucketCors - Verify that the header returned matches what is expected.
+func verifyHeaderRemoveBucketCors(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyRemoveBucketCors - Verify that the body returned is empty.
+func verifyBodyRemoveBucketCors(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainRemoveBucketCors - Entry point for the DeleteBucketCors API test.
+func mainRemoveBucketCors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucketCors:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// CORS was configured on the first s3verify created bucket by the PutBucketCors test.
+	bucketName := s3verifyBuckets[0].Name
+	// Create a new request.
+	req, err := newRemoveBucketCorsReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := removeBucketCorsVerify(res, http.StatusNoContent); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Once removed the CORS configuration should no longer be retrievable.
+	expectedError := ErrorResponse{
+		Code: "NoSuchCORSConfiguration",
+	}
+	getReq, err := newGetBucketCorsReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Verify the request failed as expected.
+	if err := getBucketCorsVerify(getRes, http.StatusNotFound, corsConfiguration{}, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,162 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newRemoveBucketEncryptionReq - Create a new HTTP request for the DeleteBucketEncryption API.
+func newRemoveBucketEncryptionReq(bucketName string) (Request, error) {
+	// removeBucketEncryptionReq - a new HTTP request for the DeleteBucketEncryption API.
+	var removeBucketEncryptionReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removeBucketEncryptionReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("encryption", "")
+	removeBucketEncryptionReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removeBucketEncryptionReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removeBucketEncryptionReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removeBucketEncryptionReq, nil
+}
+
+// removeBucketEncryptionVerify - Verify that the response returned matches what is expected.
+func removeBucketEncryptionVerify(res *http.Response, expectedStatusCode int) error {
+	if err := verifyStatusRemoveBucketEncryption(res.StatusCode, expectedStatusCode); err != nil {
+		return err
+	}
+	if err := verifyHeaderRemoveBucketEncryption(res.Header); err != nil {
+		return err
+	}
+	if err := verifyBodyRemoveBucketEncryption(res.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyStatusRemoveBucketEncryption - Verify that the status returned matches what is expected.
+func verifyStatusRemoveBucketEncryption(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %d, got %d", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderRemoveBucketEncryption - Verify that the header returned matches what is expected.
+func verifyHeaderRemoveBucketEncryption(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyRemoveBucketEncryption - Verify that the body returned is empty.
+func verifyBodyRemoveBucketEncryption(resBody io.Reader) error {
+	body, err := ioutil.ReadAll(resBody)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, []byte{}) {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		return err
+	}
+	return nil
+}
+
+// mainRemoveBucketEncryption - Entry point for the DeleteBucketEncryption API test.
+func mainRemoveBucketEncryption(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucketEncryption:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Default encryption was configured on the second s3verify created bucket by the PutBucketEncryption test.
+	bucketName := s3verifyBuckets[1].Name
+	// Create a new request.
+	req, err := newRemoveBucketEncryptionReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Spin scanBar
+	scanBar(message)
+	// Verify the response.
+	if err := removeBucketEncryptionVerify(res, http.StatusNoContent); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	getReq, err := newGetBucketEncryptionReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	getRes, err := config.execRequest("GET", getReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(getRes)
+	// Once removed the configuration is either gone or, as on AWS where every bucket is encrypted, back to SSE-S3.
+	if getRes.StatusCode == http.StatusOK {
+		err = getBucketEncryptionVerify(getRes, http.StatusOK, newBucketEncryption(sseAlgorithmAES256, ""), ErrorResponse{})
+	} else {
+		expectedError := ErrorResponse{
+			Code: "ServerSideEncryptionConfigurationNotFoundError",
+		}
+		err = getBucketEncryptionVerify(getRes, http.StatusNotFound, serverSideEncryptionConfiguration{}, expectedError)
+	}
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
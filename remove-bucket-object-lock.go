@@ -0,0 +1,77 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// mainRemoveBucketObjectLock - Entry point for removing the object lock enabled bucket and every version in it.
+func mainRemoveBucketObjectLock(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucket (Object Lock):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// Wait for the compliance retention to expire, if it was placed at all.
+	if compliance := retainedObjects[1]; compliance.applied {
+		// The retention never outlasts its period, so the wait is capped at it.
+		retainUntil := compliance.retention.RetainUntilDate.Add(complianceRetentionMargin)
+		if deadline := time.Now().Add(complianceRetentionPeriod + complianceRetentionMargin); retainUntil.After(deadline) {
+			retainUntil = deadline
+		}
+		for time.Now().Before(retainUntil) {
+			// Spin scanBar
+			scanBar(message)
+			time.Sleep(time.Second)
+		}
+	}
+	// Remove every version that is left, deleting a version that is already gone succeeds as well.
+	for _, object := range objectLockObjects {
+		// Spin scanBar
+		scanBar(message)
+		if err := removeObjectVersion(config, object, true, http.StatusNoContent, ErrorResponse{}); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Generate the new DELETE bucket request.
+	req, err := newRemoveBucketReq(s3verifyObjectLockBucket.Name)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Perform the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := removeBucketVerify(res, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
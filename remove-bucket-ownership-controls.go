@@ -0,0 +1,95 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newRemoveBucketOwnershipControlsReq - Create a new HTTP request for the DeleteBucketOwnershipControls API.
+func newRemoveBucketOwnershipControlsReq(bucketName string) (Request, error) {
+	// removeBucketOwnershipControlsReq - a new HTTP request for the DeleteBucketOwnershipControls API.
+	var removeBucketOwnershipControlsReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removeBucketOwnershipControlsReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("ownershipControls", "")
+	removeBucketOwnershipControlsReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removeBucketOwnershipControlsReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removeBucketOwnershipControlsReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removeBucketOwnershipControlsReq, nil
+}
+
+// mainRemoveBucketOwnershipControls - Entry point for the DeleteBucketOwnershipControls API test.
+func mainRemoveBucketOwnershipControls(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucketOwnershipControls:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyAccessBucket.Name
+	// Create a new request.
+	req, err := newRemoveBucketOwnershipControlsReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyEmptyBody(res); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Once removed the ownership controls should no longer be retrievable.
+	if err := getBucketOwnershipControls(config, bucketName, ownershipControls{}, http.StatusNotFound, ErrorResponse{Code: "OwnershipControlsNotFoundError"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,104 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// newRemoveBucketWebsiteReq - Create a new HTTP request for the DeleteBucketWebsite API.
+func newRemoveBucketWebsiteReq(bucketName string) (Request, error) {
+	// removeBucketWebsiteReq - a new HTTP request for the DeleteBucketWebsite API.
+	var removeBucketWebsiteReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removeBucketWebsiteReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("website", "")
+	removeBucketWebsiteReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removeBucketWebsiteReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removeBucketWebsiteReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removeBucketWebsiteReq, nil
+}
+
+// mainRemoveBucketWebsite - Entry point for the DeleteBucketWebsite API test.
+func mainRemoveBucketWebsite(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveBucketWebsite:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	// The website was configured on the first s3verify created bucket by the PutBucketWebsite test.
+	bucketName := s3verifyBuckets[0].Name
+	// Create a new request.
+	req, err := newRemoveBucketWebsiteReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != http.StatusNoContent {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusNoContent, res.StatusCode)
+		printMessage(message, err)
+		return false
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if len(body) != 0 {
+		err := fmt.Errorf("Unexpected Body Received: %v", string(body))
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Once removed the website configuration should no longer be retrievable.
+	if err := getBucketWebsite(config, bucketName, websiteConfiguration{}, http.StatusNotFound, ErrorResponse{Code: "NoSuchWebsiteConfiguration"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -98,6 +98,22 @@ func verifyStatusRemoveBucket(respStatusCode, expectedStatusCode int) error {
 	return nil
 }
 
+// removeBucket - Remove an empty bucket.
+func removeBucket(config ServerConfig, bucketName string) error {
+	req, err := newRemoveBucketReq(bucketName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return removeBucketVerify(res, http.StatusNoContent, ErrorResponse{})
+}
+
 // mainRemoveBucketExists - test the removebucket API.
 func mainRemoveBucketExists(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] RemoveBucket (Bucket Exists):", curTest, globalTotalNumTest)
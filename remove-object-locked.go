@@ -0,0 +1,89 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// removeObjectVersion - Delete a single object version and verify the response.
+func removeObjectVersion(config ServerConfig, object *ObjectInfo, bypassGovernance bool, expectedStatusCode int, expectedError ErrorResponse) error {
+	req, err := newRemoveObjectVersionReq(config, s3verifyObjectLockBucket.Name, object.Key, object.VersionID, bypassGovernance)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return removeObjectVersionVerify(res, expectedStatusCode, expectedError)
+}
+
+// mainRemoveObjectLocked - Entry point for the RemoveObject test on locked object versions.
+func mainRemoveObjectLocked(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemoveObject (Object Lock):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	expectedError := ErrorResponse{
+		Code: "AccessDenied",
+	}
+	// A version under legal hold cannot be deleted, even when bypassing governance retention.
+	if err := removeObjectVersion(config, legalHoldObject, true, http.StatusForbidden, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Once released the version can be deleted.
+	if err := putObjectLegalHold(config, legalHoldObject, legalHoldOff); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := removeObjectVersion(config, legalHoldObject, false, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// A version in governance mode can only be deleted when bypassing governance retention.
+	governance := retainedObjects[0]
+	if err := removeObjectVersion(config, governance.object, false, http.StatusForbidden, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := removeObjectVersion(config, governance.object, true, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// A version in compliance mode cannot be deleted until its retention expires.
+	compliance := retainedObjects[1]
+	if err := removeObjectVersion(config, compliance.object, true, http.StatusForbidden, expectedError); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
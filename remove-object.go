@@ -92,6 +92,22 @@ func verifyStatusRemoveObject(respStatusCode, expectedStatusCode int) error {
 	return nil
 }
 
+// removeObject - Remove a single object, removing an object that does not exist also succeeds.
+func removeObject(config ServerConfig, bucketName, objectName string) error {
+	req, err := newRemoveObjectReq(config, bucketName, objectName)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return removeObjectVerify(res, http.StatusNoContent)
+}
+
 // mainRemoveObjectExists - RemoveObject API test when object exists.
 func mainRemoveObjectExists(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%d/%d] RemoveObject:", curTest, globalTotalNumTest)
@@ -100,78 +116,31 @@ func mainRemoveObjectExists(config ServerConfig, curTest int) bool {
 	// Only remove objects from s3verify created buckets.
 	// Only remove s3verify created objects.
 	for _, bucket := range s3verifyBuckets {
-		for _, object := range s3verifyObjects {
-			// Spin scanBar
-			scanBar(message)
-			// Create a new request.
-			req, err := newRemoveObjectReq(config, bucket.Name, object.Key)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			// Execute the request.
-			res, err := config.execRequest("DELETE", req)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			defer closeResponse(res)
-			// Verify the response.
-			if err := removeObjectVerify(res, http.StatusNoContent); err != nil {
-				printMessage(message, err)
-				return false
-			}
-			// Spin scanBar
-			scanBar(message)
-
-		}
-		for _, object := range copyObjects {
-			// Spin scanBar
-			scanBar(message)
-			// Create a new request.
-			req, err := newRemoveObjectReq(config, bucket.Name, object.Key)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			// Execute the request.
-			res, err := config.execRequest("DELETE", req)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			defer closeResponse(res)
-			// Verify the response.
-			if err := removeObjectVerify(res, http.StatusNoContent); err != nil {
-				printMessage(message, err)
-				return false
-			}
-			// Spin scanBar
-			scanBar(message)
-		}
-		for _, object := range multipartObjects {
-			// Spin scanBar
-			scanBar(message)
-			// Create a new request.
-			req, err := newRemoveObjectReq(config, bucket.Name, object.Key)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			// Execute the request.
-			res, err := config.execRequest("DELETE", req)
-			if err != nil {
-				printMessage(message, err)
-				return false
-			}
-			defer closeResponse(res)
-			// Verify the response.
-			if err := removeObjectVerify(res, http.StatusNoContent); err != nil {
-				printMessage(message, err)
-				return false
+		for _, objects := range [][]*ObjectInfo{s3verifyObjects, copyObjects, multipartObjects, sseCustomerObjects, sseObjects, uploadPartCopyObjects, keyNameObjects, rangeObjects, metadataObjects, checksumObjects, conditionalObjects, selectObjects, notificationObjects, websiteObjects, storageClassObjects, attributesObjects} {
+			for _, object := range objects {
+				// Spin scanBar
+				scanBar(message)
+				// Create a new request.
+				req, err := newRemoveObjectReq(config, bucket.Name, object.Key)
+				if err != nil {
+					printMessage(message, err)
+					return false
+				}
+				// Execute the request.
+				res, err := config.execRequest("DELETE", req)
+				if err != nil {
+					printMessage(message, err)
+					return false
+				}
+				defer closeResponse(res)
+				// Verify the response.
+				if err := removeObjectVerify(res, http.StatusNoContent); err != nil {
+					printMessage(message, err)
+					return false
+				}
+				// Spin scanBar
+				scanBar(message)
 			}
-			// Spin scanBar
-			scanBar(message)
 		}
 	}
 	// Spin scanBar
@@ -0,0 +1,95 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// newRemovePublicAccessBlockReq - Create a new HTTP request for the DeletePublicAccessBlock API.
+func newRemovePublicAccessBlockReq(bucketName string) (Request, error) {
+	// removePublicAccessBlockReq - a new HTTP request for the DeletePublicAccessBlock API.
+	var removePublicAccessBlockReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName.
+	removePublicAccessBlockReq.bucketName = bucketName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("publicAccessBlock", "")
+	removePublicAccessBlockReq.queryValues = urlValues
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because DELETE requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	removePublicAccessBlockReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	removePublicAccessBlockReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return removePublicAccessBlockReq, nil
+}
+
+// mainRemovePublicAccessBlock - Entry point for the DeletePublicAccessBlock API test.
+func mainRemovePublicAccessBlock(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RemovePublicAccessBlock:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyAccessBucket.Name
+	// Create a new request.
+	req, err := newRemovePublicAccessBlockReq(bucketName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	res, err := config.execRequest("DELETE", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := verifyAccessControlResponse(res, http.StatusNoContent, ErrorResponse{}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := verifyEmptyBody(res); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Once removed the public access block should no longer be retrievable.
+	if err := getPublicAccessBlock(config, bucketName, publicAccessBlockConfiguration{}, http.StatusNotFound, ErrorResponse{Code: "NoSuchPublicAccessBlockConfiguration"}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
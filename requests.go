@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/minio/s3verify/signv4"
@@ -33,6 +34,10 @@ import (
 type Request struct {
 	presignURL bool  // Indicates whether or not this will be a presigned http.Request.
 	expires    int64 // Describes for how long the presigned URL will be valid for.
+	anonymous  bool  // Indicates whether or not this http.Request will be sent unsigned.
+
+	signingRegion string // Region to sign for instead of the server region, used to test mismatched regions.
+	virtualHost   bool   // Address the bucket as a subdomain of the endpoint, as website endpoints require.
 
 	customHeader http.Header
 	contentBody  io.Reader
@@ -121,7 +126,7 @@ func (c ServerConfig) execRequest(method string, customReq Request) (resp *http.
 // newRequest - create an HTTP request out of a customRequest.
 func (c ServerConfig) newRequest(method string, customReq Request) (req *http.Request, err error) {
 	// Construct a new target URL.
-	targetURL, err := makeTargetURL(c.Endpoint, customReq.bucketName, customReq.objectName, c.Region, customReq.queryValues)
+	targetURL, err := makeTargetURL(c.Endpoint, customReq.bucketName, customReq.objectName, c.Region, customReq.queryValues, customReq.virtualHost)
 	if err != nil {
 		return nil, err
 	}
@@ -140,8 +145,12 @@ func (c ServerConfig) newRequest(method string, customReq Request) (req *http.Re
 		req.Body = ioutil.NopCloser(customReq.contentBody)
 	}
 
-	// Set all headers.
+	// Set all headers, user metadata keys are sent as written so their case reaches the server.
 	for k, v := range customReq.customHeader {
+		if strings.HasPrefix(http.CanonicalHeaderKey(k), userMetadataPrefix) {
+			req.Header[k] = []string{v[0]}
+			continue
+		}
 		req.Header.Set(k, v[0])
 	}
 
@@ -150,13 +159,22 @@ func (c ServerConfig) newRequest(method string, customReq Request) (req *http.Re
 		req.ContentLength = customReq.contentLength
 	}
 
+	// Anonymous requests are sent without any signature.
+	if customReq.anonymous {
+		return req, nil
+	}
+
 	// Sign the request.
+	signingRegion := c.Region
+	if customReq.signingRegion != "" {
+		signingRegion = customReq.signingRegion
+	}
 	if customReq.presignURL {
 		// Presign the request.
-		req = signv4.PreSignV4(*req, c.Access, c.Secret, c.Region, customReq.expires)
+		req = signv4.PreSignV4(*req, c.Access, c.Secret, signingRegion, customReq.expires)
 	} else {
 		// Else use regular signature v4.
-		req = signv4.SignV4(*req, c.Access, c.Secret, c.Region)
+		req = signv4.SignV4(*req, c.Access, c.Secret, signingRegion)
 	}
 
 	return req, nil
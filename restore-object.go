@@ -0,0 +1,243 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Header reporting the progress of a restore.
+const restoreHeader = "X-Amz-Restore"
+
+// The fastest restore tier, the only one that completes within the run.
+const restoreTierExpedited = "Expedited"
+
+// Expedited restores complete within minutes, the restore header is checked every restorePollInterval until then.
+const (
+	restoreTimeout      = 5 * time.Minute
+	restorePollInterval = 10 * time.Second
+)
+
+// Matches x-amz-restore: ongoing-request="false", expiry-date="Fri, 21 Dec 2012 00:00:00 GMT"
+var restoreHeaderRegexp = regexp.MustCompile(`^ongoing-request="(true|false)"(?:,\s*expiry-date="([^"]+)")?$`)
+
+// newRestoreObjectReq - Create a new HTTP request for the RestoreObject API.
+func newRestoreObjectReq(bucketName, objectName string, restore restoreRequest) (Request, error) {
+	// restoreObjectReq - a new HTTP request for the RestoreObject API.
+	var restoreObjectReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	restoreObjectReq.bucketName = bucketName
+	restoreObjectReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("restore", "")
+	restoreObjectReq.queryValues = urlValues
+
+	restoreBytes, err := xml.Marshal(restore)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(restoreBytes)
+	md5Sum, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the body, header and content length.
+	restoreObjectReq.contentBody = reader
+	restoreObjectReq.contentLength = contentLength
+	restoreObjectReq.customHeader.Set("Content-MD5", base64.StdEncoding.EncodeToString(md5Sum))
+	restoreObjectReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	restoreObjectReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return restoreObjectReq, nil
+}
+
+// restoreObject - Request the restore of an object, returning the status and the error code received if any.
+func restoreObject(config ServerConfig, bucketName, objectName string, restore restoreRequest) (int, string, error) {
+	req, err := newRestoreObjectReq(bucketName, objectName, restore)
+	if err != nil {
+		return 0, "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer closeResponse(res)
+	if res.StatusCode == http.StatusOK || res.StatusCode == http.StatusAccepted {
+		return res.StatusCode, "", verifyStandardHeaders(res.Header)
+	}
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(res.Body, &receivedError); err != nil {
+		return 0, "", err
+	}
+	return res.StatusCode, receivedError.Code, nil
+}
+
+// headObjectRestore - HEAD an object and parse its restore header, reporting whether the restore is ongoing.
+func headObjectRestore(config ServerConfig, bucketName, objectName string) (bool, time.Time, error) {
+	req, err := newHeadObjectReq(bucketName, objectName)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		return false, time.Time{}, err
+	}
+	matches := restoreHeaderRegexp.FindStringSubmatch(res.Header.Get(restoreHeader))
+	if matches == nil {
+		err := fmt.Errorf("Unexpected %s Received: %q", restoreHeader, res.Header.Get(restoreHeader))
+		return false, time.Time{}, err
+	}
+	if matches[1] == "true" {
+		return true, time.Time{}, nil
+	}
+	// A completed restore must say when the restored copy expires.
+	expiryDate, err := http.ParseTime(matches[2])
+	if err != nil {
+		err := fmt.Errorf("Unexpected %s Received: %q", restoreHeader, res.Header.Get(restoreHeader))
+		return false, time.Time{}, err
+	}
+	return false, expiryDate, nil
+}
+
+// mainRestoreObject - Entry point for the RestoreObject API test.
+func mainRestoreObject(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] RestoreObject:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// The objects were uploaded by the PutObject (Storage Class) test.
+	var archived, standard *ObjectInfo
+	for _, object := range storageClassObjects {
+		switch object.StorageClass {
+		case storageClassGlacier:
+			archived = object
+		case storageClassStandard:
+			standard = object
+		}
+	}
+	if archived == nil || standard == nil {
+		err := fmt.Errorf("Storage class objects were not created")
+		printMessage(message, err)
+		return false
+	}
+	restore := restoreRequest{
+		Days: 1,
+		GlacierJobParameters: &glacierJobParameters{
+			Tier: restoreTierExpedited,
+		},
+	}
+	// Only archived objects can be restored.
+	status, code, err := restoreObject(config, bucketName, standard.Key, restore)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if status != http.StatusForbidden || code != "InvalidObjectState" {
+		err := fmt.Errorf("Unexpected Response Received for %s: wanted %v InvalidObjectState, got %v %s", standard.StorageClass, http.StatusForbidden, status, code)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The first restore of an archived object is accepted and runs in the background.
+	status, code, err = restoreObject(config, bucketName, archived.Key, restore)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if status != http.StatusAccepted {
+		err := fmt.Errorf("Unexpected Response Received: wanted %v, got %v %s", http.StatusAccepted, status, code)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	ongoing, expiryDate, err := headObjectRestore(config, bucketName, archived.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Restoring again is rejected while the restore is ongoing and extends the restored copy once it completed.
+	status, code, err = restoreObject(config, bucketName, archived.Key, restore)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if status != http.StatusOK && (status != http.StatusConflict || code != "RestoreAlreadyInProgress") {
+		err := fmt.Errorf("Unexpected Response Received: wanted %v or %v RestoreAlreadyInProgress, got %v %s", http.StatusOK, http.StatusConflict, status, code)
+		printMessage(message, err)
+		return false
+	}
+	// Wait for the restore to complete.
+	for deadline := time.Now().Add(restoreTimeout); ongoing; {
+		if time.Now().After(deadline) {
+			err := fmt.Errorf("Restore of %s did not complete within %v", archived.Key, restoreTimeout)
+			printMessage(message, err)
+			return false
+		}
+		// Spin scanBar
+		scanBar(message)
+		time.Sleep(restorePollInterval)
+		ongoing, expiryDate, err = headObjectRestore(config, bucketName, archived.Key)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	if !expiryDate.After(time.Now()) {
+		err := fmt.Errorf("Unexpected Expiry Date Received: wanted a future date, got %v", expiryDate)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The restored copy can be read while the object keeps its storage class.
+	if err := verifyObjectBody(config, bucketName, archived); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := headObjectStorageClass(config, bucketName, archived); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -111,6 +111,9 @@ type listBucketV2Result struct {
 	ContinuationToken string
 	Prefix            string
 
+	// The number of keys and common prefixes returned in this response.
+	KeyCount int
+
 	// FetchOwner and StartAfter are currently not used
 	FetchOwner string
 	StartAfter string
@@ -144,6 +147,9 @@ type objectPart struct {
 
 	// Size of the uploaded part data.
 	Size int64
+
+	// Only set when the upload was created with a checksum algorithm.
+	objectChecksums
 }
 
 // completeMultipartUploadResult container for completed multipart
@@ -153,6 +159,8 @@ type completeMultipartUploadResult struct {
 	Bucket   string
 	Key      string
 	ETag     string
+	// Only set when the upload was created with a checksum algorithm.
+	objectChecksums
 }
 
 // listMultipartUploadsResult container for ListMultipartUploads result.
@@ -161,12 +169,14 @@ type listMultipartUploadsResult struct {
 	KeyMarker      string
 	UploadIDMarker string `xml:"UploadIdMarker"`
 	NextKeyMarker  string
-	EncodingType   string
-	MaxUploads     int64
-	IsTruncated    bool
-	Uploads        []ObjectMultipartInfo `xml:"Upload"`
-	Prefix         string
-	Delimiter      string
+	// NextUploadIDMarker is only set when the result is truncated.
+	NextUploadIDMarker string `xml:"NextUploadIdMarker"`
+	EncodingType       string
+	MaxUploads         int64
+	IsTruncated        bool
+	Uploads            []ObjectMultipartInfo `xml:"Upload"`
+	Prefix             string
+	Delimiter          string
 	// A response can contain CommonPrefixes only if you specify a delimiter.
 	CommonPrefixes []commonPrefix
 }
@@ -179,6 +189,8 @@ type completePart struct {
 	// Part number identifies the part.
 	PartNumber int
 	ETag       string
+	// Checksum of the part, only sent for uploads created with a checksum algorithm.
+	objectChecksums
 }
 
 // completeMultipartUpload container for completing multipart upload.
@@ -203,3 +215,351 @@ type listObjectPartsResult struct {
 
 	EncodingType string
 }
+
+// corsConfiguration container for the CORS configuration of a bucket.
+type corsConfiguration struct {
+	XMLName   xml.Name   `xml:"CORSConfiguration" json:"-"`
+	CORSRules []corsRule `xml:"CORSRule"`
+}
+
+// corsRule container for a single CORS rule, part of corsConfiguration.
+type corsRule struct {
+	AllowedHeaders []string `xml:"AllowedHeader"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader"`
+	MaxAgeSeconds  int      `xml:",omitempty"`
+}
+
+// serverSideEncryptionConfiguration container for the default encryption configuration of a bucket.
+type serverSideEncryptionConfiguration struct {
+	XMLName xml.Name                   `xml:"ServerSideEncryptionConfiguration" json:"-"`
+	Rules   []serverSideEncryptionRule `xml:"Rule"`
+}
+
+// serverSideEncryptionRule container for a single default encryption rule, part of serverSideEncryptionConfiguration.
+type serverSideEncryptionRule struct {
+	ApplyServerSideEncryptionByDefault applyServerSideEncryptionByDefault
+}
+
+// applyServerSideEncryptionByDefault container for the encryption applied to objects uploaded without SSE headers.
+type applyServerSideEncryptionByDefault struct {
+	SSEAlgorithm   string
+	KMSMasterKeyID string `xml:",omitempty"`
+}
+
+// objectLockConfiguration container for the object lock configuration of a bucket.
+type objectLockConfiguration struct {
+	XMLName           xml.Name `xml:"ObjectLockConfiguration" json:"-"`
+	ObjectLockEnabled string
+	Rule              *objectLockRule `xml:",omitempty"`
+}
+
+// objectLockRule container for the default retention of a bucket, part of objectLockConfiguration.
+type objectLockRule struct {
+	DefaultRetention objectLockDefaultRetention
+}
+
+// objectLockDefaultRetention container for the retention applied to new objects, part of objectLockRule.
+type objectLockDefaultRetention struct {
+	Mode  string
+	Days  int `xml:",omitempty"`
+	Years int `xml:",omitempty"`
+}
+
+// objectRetention container for the retention of a single object version.
+type objectRetention struct {
+	XMLName         xml.Name `xml:"Retention" json:"-"`
+	Mode            string
+	RetainUntilDate time.Time
+}
+
+// objectLegalHold container for the legal hold of a single object version.
+type objectLegalHold struct {
+	XMLName xml.Name `xml:"LegalHold" json:"-"`
+	Status  string
+}
+
+// accessControlPolicy container for the ACL of a bucket or object.
+type accessControlPolicy struct {
+	XMLName           xml.Name `xml:"AccessControlPolicy" json:"-"`
+	Owner             owner
+	AccessControlList accessControlList
+}
+
+// accessControlList container for the grants of an ACL, part of accessControlPolicy.
+type accessControlList struct {
+	Grants []grant `xml:"Grant"`
+}
+
+// grant container for a single permission given to a grantee, part of accessControlList.
+type grant struct {
+	Grantee    grantee
+	Permission string
+}
+
+// grantee container for a canonical user or group given a permission, part of grant.
+type grantee struct {
+	Type        string `xml:"http://www.w3.org/2001/XMLSchema-instance type,attr"`
+	ID          string `xml:",omitempty"`
+	DisplayName string `xml:",omitempty"`
+	URI         string `xml:",omitempty"`
+}
+
+// locationConstraint container for the GetBucketLocation response.
+type locationConstraint struct {
+	XMLName  xml.Name `xml:"LocationConstraint" json:"-"`
+	Location string   `xml:",chardata"`
+}
+
+// userMetadata container for the metadata listed with an object, keyed by header name.
+type userMetadata map[string]string
+
+// UnmarshalXML decodes every element inside UserMetadata into the map.
+func (m *userMetadata) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*m = userMetadata{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			(*m)[t.Name.Local] = value
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// listObjectsMetadataResult container for a ListObjects V2 response with metadata=true.
+type listObjectsMetadataResult struct {
+	Contents []objectMetadataInfo
+}
+
+// objectMetadataInfo container for an object listed along with its metadata.
+type objectMetadataInfo struct {
+	Key          string
+	UserMetadata userMetadata
+}
+
+// objectChecksums container for the additional checksums of an object or part.
+type objectChecksums struct {
+	ChecksumCRC32     string `xml:",omitempty"`
+	ChecksumCRC32C    string `xml:",omitempty"`
+	ChecksumSHA1      string `xml:",omitempty"`
+	ChecksumSHA256    string `xml:",omitempty"`
+	ChecksumCRC64NVME string `xml:",omitempty"`
+}
+
+// getObjectAttributesResult container for the GetObjectAttributes response,
+// only the attributes requested are set.
+type getObjectAttributesResult struct {
+	ETag         string
+	Checksum     objectChecksums
+	ObjectParts  *objectAttributesParts
+	StorageClass string
+	ObjectSize   int64
+}
+
+// objectAttributesParts sub container for a page of the parts of a multipart object.
+type objectAttributesParts struct {
+	PartsCount           int
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+	Parts                []objectAttributesPart `xml:"Part"`
+}
+
+// objectAttributesPart sub container for a single part, part of objectAttributesParts.
+type objectAttributesPart struct {
+	PartNumber int
+	Size       int64
+	objectChecksums
+}
+
+// selectObjectContentRequest container for the SelectObjectContent request body.
+type selectObjectContentRequest struct {
+	XMLName             xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ SelectObjectContentRequest" json:"-"`
+	Expression          string
+	ExpressionType      string
+	InputSerialization  selectInputSerialization
+	OutputSerialization selectOutputSerialization
+	RequestProgress     selectRequestProgress
+}
+
+// selectInputSerialization sub container describing the format of the object queried.
+type selectInputSerialization struct {
+	CompressionType string
+	CSV             *selectCSVInput  `xml:",omitempty"`
+	JSON            *selectJSONInput `xml:",omitempty"`
+}
+
+// selectCSVInput sub container for CSV objects.
+type selectCSVInput struct {
+	FileHeaderInfo string
+}
+
+// selectJSONInput sub container for JSON objects.
+type selectJSONInput struct {
+	Type string
+}
+
+// selectOutputSerialization sub container describing the format of the records returned.
+type selectOutputSerialization struct {
+	CSV  *selectCSVOutput  `xml:",omitempty"`
+	JSON *selectJSONOutput `xml:",omitempty"`
+}
+
+// selectCSVOutput sub container for CSV records, the defaults are used.
+type selectCSVOutput struct{}
+
+// selectJSONOutput sub container for JSON records.
+type selectJSONOutput struct {
+	RecordDelimiter string `xml:",omitempty"`
+}
+
+// selectRequestProgress sub container enabling Progress events.
+type selectRequestProgress struct {
+	Enabled bool
+}
+
+// selectStats container for the payload of Stats and Progress events.
+type selectStats struct {
+	BytesScanned   int64
+	BytesProcessed int64
+	BytesReturned  int64
+}
+
+// notificationConfiguration container for the bucket notification configuration.
+type notificationConfiguration struct {
+	XMLName             xml.Name             `xml:"NotificationConfiguration" json:"-"`
+	QueueConfigurations []queueConfiguration `xml:"QueueConfiguration"`
+}
+
+// queueConfiguration sub container for a queue or webhook target and the events sent to it.
+type queueConfiguration struct {
+	ID     string `xml:"Id"`
+	Queue  string
+	Events []string `xml:"Event"`
+	Filter notificationFilter
+}
+
+// notificationFilter sub container limiting the keys events are sent for.
+type notificationFilter struct {
+	S3Key notificationKeyFilter
+}
+
+// notificationKeyFilter sub container for the prefix and suffix rules of a notificationFilter.
+type notificationKeyFilter struct {
+	FilterRules []filterRule `xml:"FilterRule"`
+}
+
+// filterRule sub container for a single prefix or suffix rule.
+type filterRule struct {
+	Name  string
+	Value string
+}
+
+// notificationEvent container for the JSON body of a notification sent to a webhook.
+type notificationEvent struct {
+	Records []eventRecord
+}
+
+// eventRecord container for a single event of a notification.
+type eventRecord struct {
+	EventName string `json:"eventName"`
+	S3        struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key       string `json:"key"`
+			Size      int64  `json:"size"`
+			ETag      string `json:"eTag"`
+			Sequencer string `json:"sequencer"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// websiteConfiguration container for the bucket website configuration.
+type websiteConfiguration struct {
+	XMLName               xml.Name              `xml:"WebsiteConfiguration" json:"-"`
+	IndexDocument         *websiteIndexDocument `xml:",omitempty"`
+	ErrorDocument         *websiteErrorDocument `xml:",omitempty"`
+	RedirectAllRequestsTo *websiteRedirect      `xml:",omitempty"`
+	RoutingRules          *websiteRoutingRules  `xml:",omitempty"`
+}
+
+// websiteIndexDocument sub container naming the object served for directory style paths.
+type websiteIndexDocument struct {
+	Suffix string
+}
+
+// websiteErrorDocument sub container naming the object served on 4XX errors.
+type websiteErrorDocument struct {
+	Key string
+}
+
+// websiteRedirect sub container describing where requests are redirected to.
+type websiteRedirect struct {
+	HostName             string `xml:",omitempty"`
+	Protocol             string `xml:",omitempty"`
+	ReplaceKeyPrefixWith string `xml:",omitempty"`
+	ReplaceKeyWith       string `xml:",omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+}
+
+// websiteRoutingRules sub container for the conditional redirects of a website.
+type websiteRoutingRules struct {
+	RoutingRules []websiteRoutingRule `xml:"RoutingRule"`
+}
+
+// websiteRoutingRule sub container for a single conditional redirect.
+type websiteRoutingRule struct {
+	Condition *websiteCondition `xml:",omitempty"`
+	Redirect  websiteRedirect
+}
+
+// websiteCondition sub container for the condition a routing rule applies under.
+type websiteCondition struct {
+	KeyPrefixEquals             string `xml:",omitempty"`
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// publicAccessBlockConfiguration container for the public access block settings of a bucket.
+type publicAccessBlockConfiguration struct {
+	XMLName               xml.Name `xml:"PublicAccessBlockConfiguration" json:"-"`
+	BlockPublicAcls       bool
+	IgnorePublicAcls      bool
+	BlockPublicPolicy     bool
+	RestrictPublicBuckets bool
+}
+
+// ownershipControls container for the object ownership settings of a bucket.
+type ownershipControls struct {
+	XMLName xml.Name                `xml:"OwnershipControls" json:"-"`
+	Rules   []ownershipControlsRule `xml:"Rule"`
+}
+
+// ownershipControlsRule sub container naming who owns objects uploaded to the bucket.
+type ownershipControlsRule struct {
+	ObjectOwnership string
+}
+
+// restoreRequest container for the RestoreObject request of an archived object.
+type restoreRequest struct {
+	XMLName              xml.Name              `xml:"RestoreRequest" json:"-"`
+	Days                 int                   `xml:",omitempty"`
+	GlacierJobParameters *glacierJobParameters `xml:",omitempty"`
+}
+
+// glacierJobParameters sub container selecting how fast an archived object is restored.
+type glacierJobParameters struct {
+	Tier string
+}
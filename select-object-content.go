@@ -0,0 +1,306 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Fixture queried as CSV with a header line.
+const selectCSVFixture = `name,age,city
+alice,30,paris
+bob,25,london
+carol,35,paris
+dave,40,berlin
+`
+
+// Fixture queried as JSON lines holding the same records.
+const selectJSONFixture = `{"name":"alice","age":30,"city":"paris"}
+{"name":"bob","age":25,"city":"london"}
+{"name":"carol","age":35,"city":"paris"}
+{"name":"dave","age":40,"city":"berlin"}
+`
+
+// Holds all objects created by the SelectObjectContent tests.
+var selectObjects = []*ObjectInfo{}
+
+// selectResult - The events of a SelectObjectContent response.
+type selectResult struct {
+	// Payloads of all Records events concatenated.
+	records  []byte
+	stats    *selectStats
+	progress []selectStats
+	ended    bool
+	// Set by an error event or an error response sent before the event stream.
+	errorCode    string
+	errorMessage string
+}
+
+// newSelectObjectContentReq - Create a new HTTP request for the SelectObjectContent API.
+func newSelectObjectContentReq(bucketName, objectName string, selectRequest *selectObjectContentRequest) (Request, error) {
+	// selectObjectContentReq - a new HTTP request for SelectObjectContent.
+	var selectObjectContentReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	selectObjectContentReq.bucketName = bucketName
+	selectObjectContentReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("select", "")
+	urlValues.Set("select-type", "2")
+	selectObjectContentReq.queryValues = urlValues
+
+	selectRequestBytes, err := xml.Marshal(selectRequest)
+	if err != nil {
+		return Request{}, err
+	}
+	reader := bytes.NewReader(selectRequestBytes)
+	_, sha256Sum, contentLength, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the Body, Header, ContentLength of the request.
+	selectObjectContentReq.contentLength = contentLength
+	selectObjectContentReq.contentBody = reader
+	selectObjectContentReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	selectObjectContentReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return selectObjectContentReq, nil
+}
+
+// newSelectRequest - A SQL query against a CSV or JSON lines object returning records in the same format.
+func newSelectRequest(expression, format string) *selectObjectContentRequest {
+	selectRequest := &selectObjectContentRequest{
+		Expression:     expression,
+		ExpressionType: "SQL",
+		InputSerialization: selectInputSerialization{
+			CompressionType: "NONE",
+		},
+		RequestProgress: selectRequestProgress{
+			Enabled: true,
+		},
+	}
+	if format == "JSON" {
+		selectRequest.InputSerialization.JSON = &selectJSONInput{Type: "LINES"}
+		selectRequest.OutputSerialization.JSON = &selectJSONOutput{RecordDelimiter: "\n"}
+	} else {
+		selectRequest.InputSerialization.CSV = &selectCSVInput{FileHeaderInfo: "USE"}
+		selectRequest.OutputSerialization.CSV = &selectCSVOutput{}
+	}
+	return selectRequest
+}
+
+// decodeSelectEvents - Collect the records, stats, progress and errors sent in the event stream.
+func decodeSelectEvents(messages []eventStreamMessage) (selectResult, error) {
+	result := selectResult{}
+	for i, message := range messages {
+		// Nothing may follow an End or error event.
+		if result.ended || result.errorCode != "" {
+			err := fmt.Errorf("Unexpected Event Received after the end of the stream: message %d of %d", i+1, len(messages))
+			return result, err
+		}
+		if message.messageType() == "error" {
+			result.errorCode = message.headers[":error-code"]
+			result.errorMessage = message.headers[":error-message"]
+			continue
+		}
+		switch message.eventType() {
+		case "Records":
+			result.records = append(result.records, message.payload...)
+		case "Stats":
+			stats := selectStats{}
+			if err := xmlDecoder(bytes.NewReader(message.payload), &stats); err != nil {
+				return result, err
+			}
+			result.stats = &stats
+		case "Progress":
+			progress := selectStats{}
+			if err := xmlDecoder(bytes.NewReader(message.payload), &progress); err != nil {
+				return result, err
+			}
+			result.progress = append(result.progress, progress)
+		case "Cont":
+			// Keep-alive messages carry no data.
+		case "End":
+			result.ended = true
+		default:
+			err := fmt.Errorf("Unexpected Event Type Received: %q", message.eventType())
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// selectObjectContent - Run a query against an object and return the decoded events.
+func selectObjectContent(config ServerConfig, bucketName, objectName string, selectRequest *selectObjectContentRequest) (selectResult, error) {
+	req, err := newSelectObjectContentReq(bucketName, objectName, selectRequest)
+	if err != nil {
+		return selectResult{}, err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return selectResult{}, err
+	}
+	defer closeResponse(res)
+	// Errors found before the query starts are sent as a regular error response.
+	if res.StatusCode == http.StatusBadRequest {
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(res.Body, &receivedError); err != nil {
+			return selectResult{}, err
+		}
+		return selectResult{errorCode: receivedError.Code, errorMessage: receivedError.Message}, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", http.StatusOK, res.StatusCode)
+		return selectResult{}, err
+	}
+	messages, err := decodeEventStream(res.Body)
+	if err != nil {
+		return selectResult{}, err
+	}
+	return decodeSelectEvents(messages)
+}
+
+// verifySelectResult - Verify the records returned and that the stats account for the whole object.
+func verifySelectResult(result selectResult, expectedRecords string, objectSize int64) error {
+	if result.errorCode != "" {
+		err := fmt.Errorf("Unexpected Error Event Received: %s: %s", result.errorCode, result.errorMessage)
+		return err
+	}
+	if string(result.records) != expectedRecords {
+		err := fmt.Errorf("Unexpected Records Received: wanted %q, got %q", expectedRecords, string(result.records))
+		return err
+	}
+	if !result.ended {
+		err := fmt.Errorf("Unexpected End of Event Stream: no End event received")
+		return err
+	}
+	if result.stats == nil {
+		err := fmt.Errorf("Unexpected End of Event Stream: no Stats event received")
+		return err
+	}
+	expectedStats := selectStats{
+		BytesScanned:   objectSize,
+		BytesProcessed: objectSize,
+		BytesReturned:  int64(len(result.records)),
+	}
+	if *result.stats != expectedStats {
+		err := fmt.Errorf("Unexpected Stats Received: wanted %+v, got %+v", expectedStats, *result.stats)
+		return err
+	}
+	// Progress is only sent while the query runs so it can never exceed the final stats.
+	var previous selectStats
+	for _, progress := range result.progress {
+		if progress.BytesScanned < previous.BytesScanned || progress.BytesScanned > expectedStats.BytesScanned {
+			err := fmt.Errorf("Unexpected Progress Received: %+v after %+v with final stats %+v", progress, previous, expectedStats)
+			return err
+		}
+		previous = progress
+	}
+	return nil
+}
+
+// mainSelectObjectContent - Entry point for the SelectObjectContent test on CSV and JSON objects.
+func mainSelectObjectContent(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] SelectObjectContent:", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	csvObject := &ObjectInfo{
+		Key:  "s3verify/select/people.csv",
+		Body: []byte(selectCSVFixture),
+	}
+	jsonObject := &ObjectInfo{
+		Key:  "s3verify/select/people.json",
+		Body: []byte(selectJSONFixture),
+	}
+	// Save the objects so they are removed later.
+	selectObjects = append(selectObjects, csvObject, jsonObject)
+	for _, object := range selectObjects {
+		if err := putObject(config, bucketName, object); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	testCases := []struct {
+		object          *ObjectInfo
+		format          string
+		expression      string
+		expectedRecords string
+	}{
+		// Projections.
+		{csvObject, "CSV", "SELECT s.name FROM S3Object s", "alice\nbob\ncarol\ndave\n"},
+		{jsonObject, "JSON", "SELECT s.name FROM S3Object s", "{\"name\":\"alice\"}\n{\"name\":\"bob\"}\n{\"name\":\"carol\"}\n{\"name\":\"dave\"}\n"},
+		// Filters.
+		{csvObject, "CSV", "SELECT s.name, s.age FROM S3Object s WHERE s.city = 'paris'", "alice,30\ncarol,35\n"},
+		{jsonObject, "JSON", "SELECT s.name FROM S3Object s WHERE s.age > 28 AND s.city <> 'paris'", "{\"name\":\"dave\"}\n"},
+		// Aggregates.
+		{csvObject, "CSV", "SELECT COUNT(*) FROM S3Object s WHERE CAST(s.age AS INT) > 28", "3\n"},
+		{csvObject, "CSV", "SELECT SUM(CAST(s.age AS INT)) FROM S3Object s", "130\n"},
+		{jsonObject, "JSON", "SELECT MAX(s.age) FROM S3Object s", "{\"_1\":40}\n"},
+	}
+	for _, testCase := range testCases {
+		// Spin scanBar
+		scanBar(message)
+		result, err := selectObjectContent(config, bucketName, testCase.object.Key, newSelectRequest(testCase.expression, testCase.format))
+		if err != nil {
+			err := fmt.Errorf("%s: %v", testCase.expression, err)
+			printMessage(message, err)
+			return false
+		}
+		if err := verifySelectResult(result, testCase.expectedRecords, int64(len(testCase.object.Body))); err != nil {
+			err := fmt.Errorf("%s: %v", testCase.expression, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Queries that can not run must report an error, either before or during the event stream.
+	for _, expression := range []string{
+		"SELECT FROM S3Object",
+		"SELECT s.name FROM S3Object s WHERE",
+		"SELECT CAST(s.name AS INT) FROM S3Object s",
+	} {
+		// Spin scanBar
+		scanBar(message)
+		result, err := selectObjectContent(config, bucketName, csvObject.Key, newSelectRequest(expression, "CSV"))
+		if err != nil {
+			err := fmt.Errorf("%s: %v", expression, err)
+			printMessage(message, err)
+			return false
+		}
+		if result.errorCode == "" {
+			err := fmt.Errorf("%s: Unexpected Success: wanted an error, got records %q", expression, string(result.records))
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
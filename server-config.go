@@ -28,21 +28,31 @@ import (
 // ServerConfig - container for all the user passed server info
 // and a reusable http.Client
 type ServerConfig struct {
-	Access   string
-	Secret   string
-	Endpoint string
-	Region   string
-	Client   *http.Client
+	Access         string
+	Secret         string
+	Endpoint       string
+	Region         string
+	KMSKeyID       string // KMS key used by SSE-KMS tests.
+	WebhookARN     string // Webhook target the server sends bucket notifications to.
+	WebhookAddress string // Local address s3verify receives bucket notifications on.
+	WebsiteURL     string // Website endpoint of the server, buckets are addressed as subdomains of its host.
+	LargeObjects   bool   // Whether tests creating objects over 5GB are run.
+	Client         *http.Client
 }
 
 // newServerConfig - new server config.
 func newServerConfig(ctx *cli.Context) *ServerConfig {
 	// Set config fields from either flags or env. variables.
 	serverCfg := &ServerConfig{
-		Access:   ctx.String("access"),
-		Secret:   ctx.String("secret"),
-		Endpoint: ctx.String("url"),
-		Region:   ctx.String("region"),
+		Access:         ctx.String("access"),
+		Secret:         ctx.String("secret"),
+		Endpoint:       ctx.String("url"),
+		Region:         ctx.String("region"),
+		KMSKeyID:       ctx.String("kms-key-id"),
+		WebhookARN:     ctx.String("webhook-arn"),
+		WebhookAddress: ctx.String("webhook-address"),
+		WebsiteURL:     ctx.String("website-url"),
+		LargeObjects:   ctx.Bool("large-objects") || ctx.GlobalBool("large-objects"),
 		Client: &http.Client{
 			Transport: &http.Transport{
 				Dial: (&net.Dialer{
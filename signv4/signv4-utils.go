@@ -94,3 +94,9 @@ func urlEncodePath(pathName string) string {
 	}
 	return encodedPathname
 }
+
+// EncodePath encodes a path exactly as urlEncodePath does for signing, for
+// headers such as x-amz-copy-source that carry an encoded object path.
+func EncodePath(pathName string) string {
+	return urlEncodePath(pathName)
+}
@@ -0,0 +1,146 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"crypto/md5"
+	crand "crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Server side encryption with customer provided keys (SSE-C) headers.
+const (
+	sseCustomerAlgorithm = "X-Amz-Server-Side-Encryption-Customer-Algorithm"
+	sseCustomerKey       = "X-Amz-Server-Side-Encryption-Customer-Key"
+	sseCustomerKeyMD5    = "X-Amz-Server-Side-Encryption-Customer-Key-Md5"
+
+	sseCopyCustomerAlgorithm = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Algorithm"
+	sseCopyCustomerKey       = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key"
+	sseCopyCustomerKeyMD5    = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5"
+
+	// Server side encryption with S3 managed keys (SSE-S3) or KMS managed keys (SSE-KMS) headers.
+	sseHeader   = "X-Amz-Server-Side-Encryption"
+	sseKMSKeyID = "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"
+
+	// The only algorithm supported by SSE-C, also used to request SSE-S3.
+	sseAlgorithmAES256 = "AES256"
+	// The algorithm used to request SSE-KMS.
+	sseAlgorithmKMS = "aws:kms"
+)
+
+// newSSECustomerKey - Generate a new random 256 bit customer provided key.
+func newSSECustomerKey() ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(crand.Reader, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// sseCustomerKeyMD5Sum - base64 encoded MD5 of a customer provided key.
+func sseCustomerKeyMD5Sum(key []byte) string {
+	md5Sum := md5.Sum(key)
+	return base64.StdEncoding.EncodeToString(md5Sum[:])
+}
+
+// setSSECustomerHeaders - Set the SSE-C headers needed to encrypt or decrypt an object with key.
+func setSSECustomerHeaders(header http.Header, key []byte) {
+	header.Set(sseCustomerAlgorithm, sseAlgorithmAES256)
+	header.Set(sseCustomerKey, base64.StdEncoding.EncodeToString(key))
+	header.Set(sseCustomerKeyMD5, sseCustomerKeyMD5Sum(key))
+}
+
+// setCopySourceSSECustomerHeaders - Set the SSE-C headers needed to decrypt the source of a copy with key.
+func setCopySourceSSECustomerHeaders(header http.Header, key []byte) {
+	header.Set(sseCopyCustomerAlgorithm, sseAlgorithmAES256)
+	header.Set(sseCopyCustomerKey, base64.StdEncoding.EncodeToString(key))
+	header.Set(sseCopyCustomerKeyMD5, sseCustomerKeyMD5Sum(key))
+}
+
+// verifySSECustomerHeaders - Verify that a response echoes back the SSE-C algorithm and key MD5 but never the key.
+func verifySSECustomerHeaders(header http.Header, key []byte) error {
+	if algorithm := header.Get(sseCustomerAlgorithm); algorithm != sseAlgorithmAES256 {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", sseCustomerAlgorithm, sseAlgorithmAES256, algorithm)
+		return err
+	}
+	if keyMD5 := header.Get(sseCustomerKeyMD5); keyMD5 != sseCustomerKeyMD5Sum(key) {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", sseCustomerKeyMD5, sseCustomerKeyMD5Sum(key), keyMD5)
+		return err
+	}
+	if header.Get(sseCustomerKey) != "" {
+		err := fmt.Errorf("Unexpected %s Received: the customer key must never be returned", sseCustomerKey)
+		return err
+	}
+	return nil
+}
+
+// setSSEHeaders - Set the headers needed to request SSE-S3 or SSE-KMS for an object.
+func setSSEHeaders(header http.Header, algorithm, kmsKeyID string) {
+	header.Set(sseHeader, algorithm)
+	if kmsKeyID != "" {
+		header.Set(sseKMSKeyID, kmsKeyID)
+	}
+}
+
+// verifySSEHeaders - Verify that a response echoes back the SSE-S3 or SSE-KMS encryption of an object.
+func verifySSEHeaders(header http.Header, algorithm, kmsKeyID string) error {
+	if receivedAlgorithm := header.Get(sseHeader); receivedAlgorithm != algorithm {
+		err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", sseHeader, algorithm, receivedAlgorithm)
+		return err
+	}
+	if algorithm == sseAlgorithmKMS {
+		// AWS returns the ARN of the key, other servers may return the key ID itself.
+		if receivedKeyID := header.Get(sseKMSKeyID); !strings.HasSuffix(receivedKeyID, kmsKeyID) {
+			err := fmt.Errorf("Unexpected %s Received: wanted %s, got %s", sseKMSKeyID, kmsKeyID, receivedKeyID)
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySSEErrorResponse - Verify that the error returned for a server side encryption request matches what is expected.
+func verifySSEErrorResponse(resBody io.Reader, expectedError ErrorResponse) error {
+	receivedError := ErrorResponse{}
+	if err := xmlDecoder(resBody, &receivedError); err != nil {
+		return err
+	}
+	if receivedError.Code != expectedError.Code {
+		err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+		return err
+	}
+	return nil
+}
+
+// isSecureEndpoint - SSE-C requests are only allowed over TLS.
+func isSecureEndpoint(endpoint string) bool {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	return endpointURL.Scheme == "https"
+}
+
+// The error returned for SSE-C requests made over plain HTTP.
+var errSSECustomerInsecure = ErrorResponse{
+	Code:    "InvalidRequest",
+	Message: "Requests specifying Server Side Encryption with Customer provided keys must be made over a secure connection.",
+}
@@ -46,6 +46,11 @@ var preparedTests = []APItest{
 		Extended: false, // PutBucket is not an extended API.
 		Critical: false, // This test is not used for future tests.
 	},
+	APItest{
+		Test:     mainPutBucketNames,
+		Extended: true,  // Bucket naming rules are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
 
 	// Tests for GetBucketPolicy API.
 	APItest{
@@ -119,6 +124,28 @@ var preparedTests = []APItest{
 		Critical: false, // This test does not affect future tests.
 	},
 
+	// Tests for ListObjects pagination and parameters.
+	APItest{
+		Test:     mainPutBucketList,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // The listing tests fail on their own if the bucket was not created.
+	},
+	APItest{
+		Test:     mainListObjectsV1Pagination,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainListObjectsV2Pagination,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketList,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
 	// Tests for Multipart API.
 	APItest{
 		Test:     mainInitiateMultipartUpload,
@@ -140,6 +167,16 @@ var preparedTests = []APItest{
 		Extended: false, // List Multipart Uploads test must be run without extended flag being set.
 		Critical: false, // List Multipart Uploads test can fail without affecting other tests.
 	},
+	APItest{
+		Test:     mainListPartsPagination,
+		Extended: true,  // List Parts pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainListMultipartUploadsPagination,
+		Extended: true,  // List Multipart Uploads pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
 	APItest{
 		Test:     mainCompleteMultipartUpload,
 		Extended: false, // Complete Multipart test must be run even without extended flag being set.
@@ -215,206 +252,1093 @@ var preparedTests = []APItest{
 		Extended: true,  // GetObject with range header is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
+	APItest{
+		Test:     mainGetObjectRangeSemantics,
+		Extended: true,  // GetObject with range header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
 
-	// Test for RemoveObject API.
+	// Tests for Bucket CORS API.
 	APItest{
-		Test:     mainRemoveObjectExists,
-		Extended: false, // RemoveObject is not an extended API.
-		Critical: true,  // This test does affect future tests.
+		Test:     mainPutBucketCors,
+		Extended: true,  // PutBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketCors,
+		Extended: true,  // GetBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainOptionsObject,
+		Extended: true,  // CORS preflight requests are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectCors,
+		Extended: true,  // GetObject with an Origin header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketCors,
+		Extended: true,  // RemoveBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for RemoveBucket API.
+	// Tests for Server Side Encryption with customer provided keys (SSE-C).
 	APItest{
-		Test:     mainRemoveBucketExists,
-		Extended: false, // RemoveBucket is not an extended API.
-		Critical: true,  // Removing this bucket is necessary for a good test.
+		Test:     mainPutObjectSSEC,
+		Extended: true,  // PutObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainRemoveBucketDNE,
-		Extended: false, // RemoveBucket is not an extended API.
+		Test:     mainGetObjectSSEC,
+		Extended: true,  // GetObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectSSEC,
+		Extended: true,  // HeadObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectSSEC,
+		Extended: true,  // CopyObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainMultipartUploadSSEC,
+		Extended: true,  // Multipart uploads with SSE-C are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
-}
 
-// Tests - holds all tests that must be run differently based on usage of the -- flag.
-var unpreparedTests = []APItest{
-	// Tests for PutBucket API.
+	// Tests for Server Side Encryption with S3 (SSE-S3) and KMS (SSE-KMS) managed keys.
 	APItest{
-		Test:     mainPutBucket,
-		Extended: false, // PutBucket is not an extended API.
-		Critical: true,  // This test does affect future tests.
+		Test:     mainPutObjectSSE,
+		Extended: true,  // PutObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainPutBucketInvalid,
-		Extended: false, // PutBucket is not an extended API.
+		Test:     mainGetObjectSSE,
+		Extended: true,  // GetObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectSSE,
+		Extended: true,  // HeadObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectSSE,
+		Extended: true,  // CopyObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainMultipartUploadSSE,
+		Extended: true,  // Multipart uploads with SSE-S3/SSE-KMS are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketEncryption,
+		Extended: true,  // PutBucketEncryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketEncryption,
+		Extended: true,  // GetBucketEncryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectDefaultEncryption,
+		Extended: true,  // Default bucket encryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketEncryption,
+		Extended: true,  // RemoveBucketEncryption is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for GetBucketPolicy API.
+	// Tests for Object Lock, retention and legal hold.
 	APItest{
-		Test:     mainGetBucketPolicy,
-		Extended: false, // GetBucketPolicy is not an extended API.
+		Test:     mainPutBucketObjectLock,
+		Extended: true,  // PutBucket with object lock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectLegalHold,
+		Extended: true,  // PutObjectLegalHold is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectLegalHold,
+		Extended: true,  // GetObjectLegalHold is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectRetention,
+		Extended: true,  // PutObjectRetention is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectRetention,
+		Extended: true,  // GetObjectRetention is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectLockConfiguration,
+		Extended: true,  // PutObjectLockConfiguration is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectLockConfiguration,
+		Extended: true,  // GetObjectLockConfiguration is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectAttributesVersioned,
+		Extended: true,  // GetObjectAttributes on object versions is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveObjectLocked,
+		Extended: true,  // RemoveObject with object lock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketObjectLock,
+		Extended: true,  // RemoveBucket with object lock is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for PutObject API.
+	// Tests for bucket and object ACLs.
 	APItest{
-		Test:     mainPutObjectUnPrepared,
-		Extended: false, // PutObject is not an extended API.
-		Critical: true,  // These objects are necessary for future tests.
+		Test:     mainPutBucketCannedACL,
+		Extended: true,  // PutBucket with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainPresignedPutObject,
-		Extended: false, // PutObject presigned is not an extended API.
-		Critical: true,  // This object is necessary for future tests.
+		Test:     mainGetBucketACL,
+		Extended: true,  // GetBucketAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketACL,
+		Extended: true,  // PutBucketAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectCannedACL,
+		Extended: true,  // PutObject with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectCannedACL,
+		Extended: true,  // CopyObject with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectACL,
+		Extended: true,  // GetObjectAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectACL,
+		Extended: true,  // PutObjectAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketACL,
+		Extended: true,  // RemoveBucket with ACLs is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for HeadBucket API.
+	// Tests for bucket locations and regions.
 	APItest{
-		Test:     mainHeadBucket,
-		Extended: false, // HeadBucket is not an extended API.
+		Test:     mainGetBucketLocation,
+		Extended: true,  // GetBucketLocation is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketRegion,
+		Extended: true,  // PutBucket in mismatched regions is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for HeadObject API.
+	// Test for UploadPartCopy API.
 	APItest{
-		Test:     mainHeadObject,
-		Extended: false, // HeadObject is not an extended API.
-		Critical: true,  // This test affects future tests and must pass.
+		Test:     mainUploadPartCopy,
+		Extended: true,  // UploadPartCopy is an extended API.
+		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for multipart edge cases.
 	APItest{
-		Test:     mainHeadObjectIfModifiedSince,
-		Extended: true,  // HeadObject with if-modified-since header is an extended API.
+		Test:     mainUploadPartErrors,
+		Extended: true,  // Invalid part uploads are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainHeadObjectIfUnModifiedSince,
-		Extended: true,  // HeadObject with if-unmodified-since header is an extended API.
+		Test:     mainCompleteMultipartUploadErrors,
+		Extended: true,  // Invalid multipart completes are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainHeadObjectIfMatch,
-		Extended: true,  // HeadObject with if-match header is an extended API.
+		Test:     mainCompleteMultipartUploadETag,
+		Extended: true,  // Multipart ETags are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainHeadObjectIfNoneMatch,
-		Extended: true,  // HeadObject with if-none-match header is an extended API.
+		Test:     mainGetObjectPartNumber,
+		Extended: true,  // GetObject by part number is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectPartNumber,
+		Extended: true,  // HeadObject by part number is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for ListBuckets API.
+	// Tests for object key names.
 	APItest{
-		Test:     mainListBuckets,
-		Extended: false, // ListBuckets is not an extended API.
+		Test:     mainObjectKeyNames,
+		Extended: true,  // Key name encoding is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainObjectKeyNameTooLong,
+		Extended: true,  // Key name encoding is an extended test.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for ListObjects API.
+	// Tests for object metadata.
 	APItest{
-		Test:     mainListObjectsV1UnPrepared,
-		Extended: false, // ListObjects is not an extended API.
+		Test:     mainPutObjectMetadata,
+		Extended: true,  // Object metadata round-trips are an extended test.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainListObjectsV2UnPrepared,
-		Extended: false, // ListObjects is not an extended API.
+		Test:     mainCopyObjectMetadata,
+		Extended: true,  // Object metadata round-trips are an extended test.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for Multipart API.
+	// Tests for CopyObject semantics.
 	APItest{
-		Test:     mainInitiateMultipartUpload,
-		Extended: false, // Initiate Multipart test must be run even without extended flags being set.
-		Critical: true,  // Initiate Multipart test must pass before other tests can be run.
+		Test:     mainCopyObjectSemantics,
+		Extended: true,  // CopyObject semantics are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainUploadPart,
-		Extended: false, // Upload Part test must be run even without extended flag being set.
-		Critical: true,  // Upload Part test must pass before other tests can be run.
+		Test:     mainCopyObjectTooLarge,
+		Extended: true,  // CopyObject semantics are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for precondition precedence.
 	APItest{
-		Test:     mainListParts,
-		Extended: false, // List Part test must be run even without extended flag being set.
-		Critical: false, // List Part test can fail without affecting other tests.
+		Test:     mainConditionalPrecedence,
+		Extended: true,  // Precondition combinations are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for additional checksums.
 	APItest{
-		Test:     mainListMultipartUploads,
-		Extended: false, // List Multipart Uploads test must be run without extended flag being set.
-		Critical: false, // List Multipart Uploads test can fail without affecting other tests.
+		Test:     mainPutObjectChecksum,
+		Extended: true,  // Additional checksums are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainCompleteMultipartUpload,
-		Extended: false, // Complete Multipart test must be run even without extended flag being set.
-		Critical: true,  // Complete Multipart test can fail without affecting other tests.
+		Test:     mainMultipartUploadChecksum,
+		Extended: true,  // Additional checksums are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for conditional writes.
 	APItest{
-		Test:     mainAbortMultipartUpload,
-		Extended: false, // Abort Multipart test must be run even without extended flag being set.
-		Critical: false, // Abort Multipart test can fail without affecting other tests.
+		Test:     mainPutObjectConditional,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectConditionalRace,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCompleteMultipartUploadConditional,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for CopyObject API.
+	// Test for SelectObjectContent API.
 	APItest{
-		Test:     mainCopyObject,
-		Extended: false, // CopyObject is not an extended API.
+		Test:     mainSelectObjectContent,
+		Extended: true,  // SelectObjectContent is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for bucket notifications.
 	APItest{
-		Test:     mainCopyObjectIfModifiedSince,
-		Extended: true,  // CopyObject with if-modified-since header is an extended API.
+		Test:     mainPutBucketNotification,
+		Extended: true,  // Bucket notifications are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainCopyObjectIfUnModifiedSince,
-		Extended: true,  // CopyObject with if-unmodified-since header is an extended API.
+		Test:     mainGetBucketNotification,
+		Extended: true,  // Bucket notifications are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainCopyObjectIfMatch,
-		Extended: true,  // CopyObject with if-match header is an extended API.
+		Test:     mainBucketNotificationEvents,
+		Extended: true,  // Bucket notifications are an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
+
+	// Tests for static website hosting.
 	APItest{
-		Test:     mainCopyObjectIfNoneMatch,
-		Extended: true,  // CopyObject with if-none-match header is an extended API.
+		Test:     mainPutBucketWebsite,
+		Extended: true,  // PutBucketWebsite is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketWebsite,
+		Extended: true,  // GetBucketWebsite is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainWebsiteEndpoint,
+		Extended: true,  // Website endpoints are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketWebsite,
+		Extended: true,  // RemoveBucketWebsite is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
-	// Tests for GetObject API.
+	// Tests for bucket ownership controls and the public access block.
 	APItest{
-		Test:     mainGetObject,
-		Extended: false, // GetObject is not an extended API.
+		Test:     mainPutBucketOwnershipControls,
+		Extended: true,  // PutBucketOwnershipControls is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectPresigned,
-		Extended: false, // GetObject Presigned is not an extended API.
+		Test:     mainGetBucketOwnershipControls,
+		Extended: true,  // GetBucketOwnershipControls is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectIfModifiedSince,
-		Extended: true,  // GetObject with if-modified-since header is an extended API.
+		Test:     mainRemoveBucketOwnershipControls,
+		Extended: true,  // RemoveBucketOwnershipControls is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectIfUnModifiedSince,
-		Extended: true,  // GetObject with if-unmodified-since header is an extended API.
+		Test:     mainPutPublicAccessBlock,
+		Extended: true,  // PutPublicAccessBlock is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectIfMatch,
-		Extended: true,  // GetObject with if-match header is an extended API.
+		Test:     mainGetPublicAccessBlock,
+		Extended: true,  // GetPublicAccessBlock is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectIfNoneMatch,
-		Extended: true,  // GetObject with if-none-match header is an extended API.
+		Test:     mainPublicAccessBlockEnforcement,
+		Extended: true,  // Public access block enforcement is an extended test.
 		Critical: false, // This test does not affect future tests.
 	},
 	APItest{
-		Test:     mainGetObjectRange,
-		Extended: true,  // GetObject with range header is an extended API.
+		Test:     mainRemovePublicAccessBlock,
+		Extended: true,  // RemovePublicAccessBlock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketAccess,
+		Extended: true,  // RemoveBucket with access controls is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for storage classes and restoring archived objects.
+	APItest{
+		Test:     mainPutObjectStorageClass,
+		Extended: true,  // PutObject with a storage class is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRestoreObject,
+		Extended: true,  // RestoreObject is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Test for GetObjectAttributes API.
+	APItest{
+		Test:     mainGetObjectAttributes,
+		Extended: true,  // GetObjectAttributes is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Test for RemoveObject API.
+	APItest{
+		Test:     mainRemoveObjectExists,
+		Extended: false, // RemoveObject is not an extended API.
+		Critical: true,  // This test does affect future tests.
+	},
+
+	// Tests for RemoveBucket API.
+	APItest{
+		Test:     mainRemoveBucketExists,
+		Extended: false, // RemoveBucket is not an extended API.
+		Critical: true,  // Removing this bucket is necessary for a good test.
+	},
+	APItest{
+		Test:     mainRemoveBucketDNE,
+		Extended: false, // RemoveBucket is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+}
+
+// Tests - holds all tests that must be run differently based on usage of the -- flag.
+var unpreparedTests = []APItest{
+	// Tests for PutBucket API.
+	APItest{
+		Test:     mainPutBucket,
+		Extended: false, // PutBucket is not an extended API.
+		Critical: true,  // This test does affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketInvalid,
+		Extended: false, // PutBucket is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketNames,
+		Extended: true,  // Bucket naming rules are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for GetBucketPolicy API.
+	APItest{
+		Test:     mainGetBucketPolicy,
+		Extended: false, // GetBucketPolicy is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for PutObject API.
+	APItest{
+		Test:     mainPutObjectUnPrepared,
+		Extended: false, // PutObject is not an extended API.
+		Critical: true,  // These objects are necessary for future tests.
+	},
+	APItest{
+		Test:     mainPresignedPutObject,
+		Extended: false, // PutObject presigned is not an extended API.
+		Critical: true,  // This object is necessary for future tests.
+	},
+
+	// Tests for HeadBucket API.
+	APItest{
+		Test:     mainHeadBucket,
+		Extended: false, // HeadBucket is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for HeadObject API.
+	APItest{
+		Test:     mainHeadObject,
+		Extended: false, // HeadObject is not an extended API.
+		Critical: true,  // This test affects future tests and must pass.
+	},
+	APItest{
+		Test:     mainHeadObjectIfModifiedSince,
+		Extended: true,  // HeadObject with if-modified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectIfUnModifiedSince,
+		Extended: true,  // HeadObject with if-unmodified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectIfMatch,
+		Extended: true,  // HeadObject with if-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectIfNoneMatch,
+		Extended: true,  // HeadObject with if-none-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for ListBuckets API.
+	APItest{
+		Test:     mainListBuckets,
+		Extended: false, // ListBuckets is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for ListObjects API.
+	APItest{
+		Test:     mainListObjectsV1UnPrepared,
+		Extended: false, // ListObjects is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainListObjectsV2UnPrepared,
+		Extended: false, // ListObjects is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for ListObjects pagination and parameters.
+	APItest{
+		Test:     mainPutBucketList,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // The listing tests fail on their own if the bucket was not created.
+	},
+	APItest{
+		Test:     mainListObjectsV1Pagination,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainListObjectsV2Pagination,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketList,
+		Extended: true,  // Listing pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for Multipart API.
+	APItest{
+		Test:     mainInitiateMultipartUpload,
+		Extended: false, // Initiate Multipart test must be run even without extended flags being set.
+		Critical: true,  // Initiate Multipart test must pass before other tests can be run.
+	},
+	APItest{
+		Test:     mainUploadPart,
+		Extended: false, // Upload Part test must be run even without extended flag being set.
+		Critical: true,  // Upload Part test must pass before other tests can be run.
+	},
+	APItest{
+		Test:     mainListParts,
+		Extended: false, // List Part test must be run even without extended flag being set.
+		Critical: false, // List Part test can fail without affecting other tests.
+	},
+	APItest{
+		Test:     mainListMultipartUploads,
+		Extended: false, // List Multipart Uploads test must be run without extended flag being set.
+		Critical: false, // List Multipart Uploads test can fail without affecting other tests.
+	},
+	APItest{
+		Test:     mainListPartsPagination,
+		Extended: true,  // List Parts pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainListMultipartUploadsPagination,
+		Extended: true,  // List Multipart Uploads pagination is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCompleteMultipartUpload,
+		Extended: false, // Complete Multipart test must be run even without extended flag being set.
+		Critical: true,  // Complete Multipart test can fail without affecting other tests.
+	},
+	APItest{
+		Test:     mainAbortMultipartUpload,
+		Extended: false, // Abort Multipart test must be run even without extended flag being set.
+		Critical: false, // Abort Multipart test can fail without affecting other tests.
+	},
+
+	// Tests for CopyObject API.
+	APItest{
+		Test:     mainCopyObject,
+		Extended: false, // CopyObject is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectIfModifiedSince,
+		Extended: true,  // CopyObject with if-modified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectIfUnModifiedSince,
+		Extended: true,  // CopyObject with if-unmodified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectIfMatch,
+		Extended: true,  // CopyObject with if-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectIfNoneMatch,
+		Extended: true,  // CopyObject with if-none-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for GetObject API.
+	APItest{
+		Test:     mainGetObject,
+		Extended: false, // GetObject is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectPresigned,
+		Extended: false, // GetObject Presigned is not an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectIfModifiedSince,
+		Extended: true,  // GetObject with if-modified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectIfUnModifiedSince,
+		Extended: true,  // GetObject with if-unmodified-since header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectIfMatch,
+		Extended: true,  // GetObject with if-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectIfNoneMatch,
+		Extended: true,  // GetObject with if-none-match header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectRange,
+		Extended: true,  // GetObject with range header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectRangeSemantics,
+		Extended: true,  // GetObject with range header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for Bucket CORS API.
+	APItest{
+		Test:     mainPutBucketCors,
+		Extended: true,  // PutBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketCors,
+		Extended: true,  // GetBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainOptionsObject,
+		Extended: true,  // CORS preflight requests are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectCors,
+		Extended: true,  // GetObject with an Origin header is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketCors,
+		Extended: true,  // RemoveBucketCors is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for Server Side Encryption with customer provided keys (SSE-C).
+	APItest{
+		Test:     mainPutObjectSSEC,
+		Extended: true,  // PutObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectSSEC,
+		Extended: true,  // GetObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectSSEC,
+		Extended: true,  // HeadObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectSSEC,
+		Extended: true,  // CopyObject with SSE-C is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainMultipartUploadSSEC,
+		Extended: true,  // Multipart uploads with SSE-C are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for Server Side Encryption with S3 (SSE-S3) and KMS (SSE-KMS) managed keys.
+	APItest{
+		Test:     mainPutObjectSSE,
+		Extended: true,  // PutObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectSSE,
+		Extended: true,  // GetObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectSSE,
+		Extended: true,  // HeadObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectSSE,
+		Extended: true,  // CopyObject with SSE-S3/SSE-KMS is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainMultipartUploadSSE,
+		Extended: true,  // Multipart uploads with SSE-S3/SSE-KMS are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketEncryption,
+		Extended: true,  // PutBucketEncryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketEncryption,
+		Extended: true,  // GetBucketEncryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectDefaultEncryption,
+		Extended: true,  // Default bucket encryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketEncryption,
+		Extended: true,  // RemoveBucketEncryption is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for Object Lock, retention and legal hold.
+	APItest{
+		Test:     mainPutBucketObjectLock,
+		Extended: true,  // PutBucket with object lock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectLegalHold,
+		Extended: true,  // PutObjectLegalHold is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectLegalHold,
+		Extended: true,  // GetObjectLegalHold is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectRetention,
+		Extended: true,  // PutObjectRetention is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectRetention,
+		Extended: true,  // GetObjectRetention is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectLockConfiguration,
+		Extended: true,  // PutObjectLockConfiguration is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectLockConfiguration,
+		Extended: true,  // GetObjectLockConfiguration is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectAttributesVersioned,
+		Extended: true,  // GetObjectAttributes on object versions is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveObjectLocked,
+		Extended: true,  // RemoveObject with object lock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketObjectLock,
+		Extended: true,  // RemoveBucket with object lock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for bucket and object ACLs.
+	APItest{
+		Test:     mainPutBucketCannedACL,
+		Extended: true,  // PutBucket with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketACL,
+		Extended: true,  // GetBucketAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketACL,
+		Extended: true,  // PutBucketAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectCannedACL,
+		Extended: true,  // PutObject with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectCannedACL,
+		Extended: true,  // CopyObject with a canned ACL is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectACL,
+		Extended: true,  // GetObjectAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectACL,
+		Extended: true,  // PutObjectAcl is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketACL,
+		Extended: true,  // RemoveBucket with ACLs is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for bucket locations and regions.
+	APItest{
+		Test:     mainGetBucketLocation,
+		Extended: true,  // GetBucketLocation is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutBucketRegion,
+		Extended: true,  // PutBucket in mismatched regions is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Test for UploadPartCopy API.
+	APItest{
+		Test:     mainUploadPartCopy,
+		Extended: true,  // UploadPartCopy is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for multipart edge cases.
+	APItest{
+		Test:     mainUploadPartErrors,
+		Extended: true,  // Invalid part uploads are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCompleteMultipartUploadErrors,
+		Extended: true,  // Invalid multipart completes are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCompleteMultipartUploadETag,
+		Extended: true,  // Multipart ETags are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetObjectPartNumber,
+		Extended: true,  // GetObject by part number is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainHeadObjectPartNumber,
+		Extended: true,  // HeadObject by part number is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for object key names.
+	APItest{
+		Test:     mainObjectKeyNames,
+		Extended: true,  // Key name encoding is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainObjectKeyNameTooLong,
+		Extended: true,  // Key name encoding is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for object metadata.
+	APItest{
+		Test:     mainPutObjectMetadata,
+		Extended: true,  // Object metadata round-trips are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectMetadata,
+		Extended: true,  // Object metadata round-trips are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for CopyObject semantics.
+	APItest{
+		Test:     mainCopyObjectSemantics,
+		Extended: true,  // CopyObject semantics are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCopyObjectTooLarge,
+		Extended: true,  // CopyObject semantics are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for precondition precedence.
+	APItest{
+		Test:     mainConditionalPrecedence,
+		Extended: true,  // Precondition combinations are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for additional checksums.
+	APItest{
+		Test:     mainPutObjectChecksum,
+		Extended: true,  // Additional checksums are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainMultipartUploadChecksum,
+		Extended: true,  // Additional checksums are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for conditional writes.
+	APItest{
+		Test:     mainPutObjectConditional,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutObjectConditionalRace,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainCompleteMultipartUploadConditional,
+		Extended: true,  // Conditional writes are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Test for SelectObjectContent API.
+	APItest{
+		Test:     mainSelectObjectContent,
+		Extended: true,  // SelectObjectContent is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for bucket notifications.
+	APItest{
+		Test:     mainPutBucketNotification,
+		Extended: true,  // Bucket notifications are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketNotification,
+		Extended: true,  // Bucket notifications are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainBucketNotificationEvents,
+		Extended: true,  // Bucket notifications are an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for static website hosting.
+	APItest{
+		Test:     mainPutBucketWebsite,
+		Extended: true,  // PutBucketWebsite is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketWebsite,
+		Extended: true,  // GetBucketWebsite is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainWebsiteEndpoint,
+		Extended: true,  // Website endpoints are an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketWebsite,
+		Extended: true,  // RemoveBucketWebsite is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for bucket ownership controls and the public access block.
+	APItest{
+		Test:     mainPutBucketOwnershipControls,
+		Extended: true,  // PutBucketOwnershipControls is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetBucketOwnershipControls,
+		Extended: true,  // GetBucketOwnershipControls is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketOwnershipControls,
+		Extended: true,  // RemoveBucketOwnershipControls is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPutPublicAccessBlock,
+		Extended: true,  // PutPublicAccessBlock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainGetPublicAccessBlock,
+		Extended: true,  // GetPublicAccessBlock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainPublicAccessBlockEnforcement,
+		Extended: true,  // Public access block enforcement is an extended test.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemovePublicAccessBlock,
+		Extended: true,  // RemovePublicAccessBlock is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRemoveBucketAccess,
+		Extended: true,  // RemoveBucket with access controls is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Tests for storage classes and restoring archived objects.
+	APItest{
+		Test:     mainPutObjectStorageClass,
+		Extended: true,  // PutObject with a storage class is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+	APItest{
+		Test:     mainRestoreObject,
+		Extended: true,  // RestoreObject is an extended API.
+		Critical: false, // This test does not affect future tests.
+	},
+
+	// Test for GetObjectAttributes API.
+	APItest{
+		Test:     mainGetObjectAttributes,
+		Extended: true,  // GetObjectAttributes is an extended API.
 		Critical: false, // This test does not affect future tests.
 	},
 
@@ -0,0 +1,416 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Holds all objects created by the UploadPartCopy test.
+var uploadPartCopyObjects = []*ObjectInfo{}
+
+// newUploadPartCopyReq - Create a new HTTP request for the UploadPartCopy API.
+func newUploadPartCopyReq(sourceBucketName, sourceObjectName, bucketName, objectName, uploadID string, partNumber int, copyRange string) (Request, error) {
+	// uploadPartCopyReq - a new HTTP request for the UploadPartCopy API.
+	var uploadPartCopyReq = Request{
+		customHeader: http.Header{},
+	}
+
+	// Set the bucketName and objectName.
+	uploadPartCopyReq.bucketName = bucketName
+	uploadPartCopyReq.objectName = objectName
+
+	// Set the query values.
+	urlValues := make(url.Values)
+	urlValues.Set("partNumber", strconv.Itoa(partNumber))
+	urlValues.Set("uploadId", uploadID)
+	uploadPartCopyReq.queryValues = urlValues
+
+	// The part is copied by the server so no body is uploaded.
+	reader := bytes.NewReader([]byte{})
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	uploadPartCopyReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	uploadPartCopyReq.customHeader.Set("x-amz-copy-source", copySource(sourceBucketName, sourceObjectName))
+	// An empty copyRange copies the whole source object.
+	if copyRange != "" {
+		uploadPartCopyReq.customHeader.Set("x-amz-copy-source-range", copyRange)
+	}
+	uploadPartCopyReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return uploadPartCopyReq, nil
+}
+
+// uploadPartCopyVerify - Verify that the response returned matches what is expected and return the ETag of the part.
+func uploadPartCopyVerify(res *http.Response, expectedStatusCode int, expectedError ErrorResponse) (string, error) {
+	if err := verifyStatusUploadPartCopy(res.StatusCode, expectedStatusCode); err != nil {
+		return "", err
+	}
+	if err := verifyHeaderUploadPartCopy(res.Header); err != nil {
+		return "", err
+	}
+	return verifyBodyUploadPartCopy(res.Body, expectedError)
+}
+
+// verifyStatusUploadPartCopy - Verify that the status returned matches what is expected.
+func verifyStatusUploadPartCopy(respStatusCode, expectedStatusCode int) error {
+	if respStatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, respStatusCode)
+		return err
+	}
+	return nil
+}
+
+// verifyHeaderUploadPartCopy - Verify that the header returned matches what is expected.
+func verifyHeaderUploadPartCopy(header http.Header) error {
+	if err := verifyStandardHeaders(header); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyBodyUploadPartCopy - Verify that the body returned is a CopyPartResult or the expected error.
+func verifyBodyUploadPartCopy(resBody io.Reader, expectedError ErrorResponse) (string, error) {
+	if expectedError.Code != "" { // Error is expected.
+		receivedError := ErrorResponse{}
+		if err := xmlDecoder(resBody, &receivedError); err != nil {
+			return "", err
+		}
+		if receivedError.Code != expectedError.Code {
+			err := fmt.Errorf("Unexpected Error Code: wanted %s, got %s", expectedError.Code, receivedError.Code)
+			return "", err
+		}
+		return "", nil
+	}
+	// A CopyPartResult holds the same fields as a CopyObjectResult.
+	copyPartResult := copyObjectResult{}
+	if err := xmlDecoder(resBody, &copyPartResult); err != nil {
+		return "", err
+	}
+	if copyPartResult.ETag == "" {
+		err := fmt.Errorf("Unexpected CopyPartResult Received: no ETag")
+		return "", err
+	}
+	return strings.Trim(copyPartResult.ETag, "\""), nil
+}
+
+// uploadPartCopy - Execute an UploadPartCopy request and verify the response.
+func uploadPartCopy(config ServerConfig, req Request, expectedStatusCode int, expectedError ErrorResponse) (string, error) {
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return uploadPartCopyVerify(res, expectedStatusCode, expectedError)
+}
+
+// initiateMultipartUpload - Initiate a new multipart upload and return its uploadID.
+func initiateMultipartUpload(config ServerConfig, bucketName, objectName string) (string, error) {
+	req, err := newInitiateMultipartUploadReq(bucketName, objectName)
+	if err != nil {
+		return "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response and get the uploadID.
+	return initiateMultipartUploadVerify(res, http.StatusOK)
+}
+
+// completeMultipartUploadParts - Complete a multipart upload out of the ETags of its parts, numbered from 1.
+func completeMultipartUploadParts(config ServerConfig, bucketName, objectName, uploadID string, partETags []string) error {
+	complete := &completeMultipartUpload{}
+	for i, partETag := range partETags {
+		complete.Parts = append(complete.Parts, completePart{
+			PartNumber: i + 1,
+			ETag:       partETag,
+		})
+	}
+	req, err := newCompleteMultipartUploadReq(bucketName, objectName, uploadID, complete)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("POST", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return completeMultipartUploadVerify(res, http.StatusOK)
+}
+
+// verifyObjectBody - GET an object and verify it holds the expected data.
+func verifyObjectBody(config ServerConfig, bucketName string, object *ObjectInfo) error {
+	req, err := newGetObjectReq(bucketName, object.Key, nil)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return getObjectVerify(res, object.Body, http.StatusOK, nil)
+}
+
+// copyRangeHeader - Format an inclusive byte range for the x-amz-copy-source-range header.
+func copyRangeHeader(first, last int) string {
+	return fmt.Sprintf("bytes=%d-%d", first, last)
+}
+
+// mainUploadPartCopy - Entry point for the UploadPartCopy API test.
+func mainUploadPartCopy(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Upload-Part-Copy):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	// Upload a source larger than the minimum part size so it can be split into ranges.
+	source := &ObjectInfo{
+		Key:  "s3verify/upload-part-copy/source",
+		Body: make([]byte, 6*1024*1024),
+	}
+	if _, err := io.ReadFull(crand.Reader, source.Body); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	putReq, err := newPutObjectReq(bucketName, source.Key, source.Body)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	putRes, err := config.execRequest("PUT", putReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(putRes)
+	// Verify the response.
+	if err := putObjectVerify(putRes, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Save the object so it is removed later.
+	uploadPartCopyObjects = append(uploadPartCopyObjects, source)
+	// Spin scanBar
+	scanBar(message)
+
+	// Assemble a copy of the source out of two ranges.
+	ranges := &ObjectInfo{
+		Key:  "s3verify/upload-part-copy/ranges",
+		Body: source.Body,
+	}
+	ranges.UploadID, err = initiateMultipartUpload(config, bucketName, ranges.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before completing it.
+	rangesCompleted := false
+	defer func() {
+		if !rangesCompleted {
+			abortMultipartUpload(config, bucketName, ranges.Key, ranges.UploadID)
+		}
+	}()
+	partSize := 5 * 1024 * 1024
+	copyRanges := []string{
+		copyRangeHeader(0, partSize-1),
+		copyRangeHeader(partSize, len(source.Body)-1),
+	}
+	partETags := []string{}
+	for i, copyRange := range copyRanges {
+		// Spin scanBar
+		scanBar(message)
+		req, err := newUploadPartCopyReq(bucketName, source.Key, bucketName, ranges.Key, ranges.UploadID, i+1, copyRange)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partETag, err := uploadPartCopy(config, req, http.StatusOK, ErrorResponse{})
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		partETags = append(partETags, partETag)
+	}
+	if err := completeMultipartUploadParts(config, bucketName, ranges.Key, ranges.UploadID, partETags); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	rangesCompleted = true
+	// Save the object so it is removed later.
+	uploadPartCopyObjects = append(uploadPartCopyObjects, ranges)
+	// The ranges concatenated must give back the source.
+	if err := verifyObjectBody(config, bucketName, ranges); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+
+	// Copy parts out of the multipart object just completed.
+	headReq, err := newHeadObjectReq(bucketName, ranges.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Execute the request.
+	headRes, err := config.execRequest("HEAD", headReq)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(headRes)
+	// Verify the response.
+	if err := headObjectVerify(headRes, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	rangesETag := headRes.Header.Get("ETag")
+	multipart := &ObjectInfo{
+		Key: "s3verify/upload-part-copy/multipart",
+	}
+	multipart.UploadID, err = initiateMultipartUpload(config, bucketName, multipart.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before completing it.
+	multipartCompleted := false
+	defer func() {
+		if !multipartCompleted {
+			abortMultipartUpload(config, bucketName, multipart.Key, multipart.UploadID)
+		}
+	}()
+	// Spin scanBar
+	scanBar(message)
+	// Copy-source conditions that do not hold must fail the copy.
+	dayAgo := time.Now().UTC().Add(-24 * time.Hour).Format(http.TimeFormat)
+	failedConditions := []map[string]string{
+		{"x-amz-copy-source-if-match": "\"s3verify-etag\""},
+		{"x-amz-copy-source-if-none-match": rangesETag},
+		{"x-amz-copy-source-if-unmodified-since": dayAgo},
+	}
+	expectedError := ErrorResponse{
+		Code: "PreconditionFailed",
+	}
+	for _, conditions := range failedConditions {
+		// Spin scanBar
+		scanBar(message)
+		req, err := newUploadPartCopyReq(bucketName, ranges.Key, bucketName, multipart.Key, multipart.UploadID, 1, "")
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		for k, v := range conditions {
+			req.customHeader.Set(k, v)
+		}
+		if _, err := uploadPartCopy(config, req, http.StatusPreconditionFailed, expectedError); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Copy the whole multipart source as the first part when every condition holds.
+	wholeReq, err := newUploadPartCopyReq(bucketName, ranges.Key, bucketName, multipart.Key, multipart.UploadID, 1, "")
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	wholeReq.customHeader.Set("x-amz-copy-source-if-match", rangesETag)
+	wholeReq.customHeader.Set("x-amz-copy-source-if-modified-since", dayAgo)
+	wholeETag, err := uploadPartCopy(config, wholeReq, http.StatusOK, ErrorResponse{})
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Ranges outside of the source or that cannot be parsed must be refused.
+	invalidRanges := []string{
+		copyRangeHeader(len(ranges.Body), len(ranges.Body)+1023),
+		"bytes=s3verify",
+	}
+	expectedError = ErrorResponse{
+		Code: "InvalidArgument",
+	}
+	for _, invalidRange := range invalidRanges {
+		// Spin scanBar
+		scanBar(message)
+		req, err := newUploadPartCopyReq(bucketName, ranges.Key, bucketName, multipart.Key, multipart.UploadID, 2, invalidRange)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		if _, err := uploadPartCopy(config, req, http.StatusBadRequest, expectedError); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Copy a small range of the multipart source as the last part.
+	first, last := 100, 1123
+	rangeReq, err := newUploadPartCopyReq(bucketName, ranges.Key, bucketName, multipart.Key, multipart.UploadID, 2, copyRangeHeader(first, last))
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	rangeETag, err := uploadPartCopy(config, rangeReq, http.StatusOK, ErrorResponse{})
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if err := completeMultipartUploadParts(config, bucketName, multipart.Key, multipart.UploadID, []string{wholeETag, rangeETag}); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	multipartCompleted = true
+	// Save the object so it is removed later.
+	multipart.Body = append(append([]byte{}, ranges.Body...), ranges.Body[first:last+1]...)
+	uploadPartCopyObjects = append(uploadPartCopyObjects, multipart)
+	// Spin scanBar
+	scanBar(message)
+	// The completed object must hold the whole source followed by the range.
+	if err := verifyObjectBody(config, bucketName, multipart); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -0,0 +1,99 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// mainUploadPartErrors - Entry point for the upload part test with invalid part numbers and uploads.
+func mainUploadPartErrors(config ServerConfig, curTest int) bool {
+	message := fmt.Sprintf("[%02d/%d] Multipart (Upload-Part Errors):", curTest, globalTotalNumTest)
+	// Spin scanBar
+	scanBar(message)
+	bucketName := s3verifyBuckets[0].Name
+	objectName := "s3verify/multipart/part-errors"
+	uploadID, err := initiateMultipartUpload(config, bucketName, objectName)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Abort the upload if the test fails before aborting it itself.
+	aborted := false
+	defer func() {
+		if !aborted {
+			abortMultipartUpload(config, bucketName, objectName, uploadID)
+		}
+	}()
+	partData := []byte("s3verify")
+	testCases := []struct {
+		uploadID           string
+		partNumber         int
+		expectedStatusCode int
+		expectedError      ErrorResponse
+	}{
+		// Part numbers go from 1 to 10000.
+		{uploadID, 0, http.StatusBadRequest, ErrorResponse{Code: "InvalidArgument"}},
+		{uploadID, 10001, http.StatusBadRequest, ErrorResponse{Code: "InvalidArgument"}},
+		// The upload must exist.
+		{"s3verify-upload-id", 1, http.StatusNotFound, ErrorResponse{Code: "NoSuchUpload"}},
+	}
+	for _, testCase := range testCases {
+		// Spin scanBar
+		scanBar(message)
+		// Create a new request.
+		req, err := newUploadPartReq(bucketName, objectName, testCase.uploadID, testCase.partNumber, partData)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		// Execute the request.
+		res, err := config.execRequest("PUT", req)
+		if err != nil {
+			printMessage(message, err)
+			return false
+		}
+		defer closeResponse(res)
+		// Verify the request failed as expected.
+		if err := verifyMultipartErrorResponse(res, testCase.expectedStatusCode, testCase.expectedError); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The boundaries of the part number range are valid.
+	for _, partNumber := range []int{1, 10000} {
+		if _, err := uploadPart(config, bucketName, objectName, uploadID, partNumber, partData); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	if err := abortMultipartUpload(config, bucketName, objectName, uploadID); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	aborted = true
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}
@@ -121,6 +121,25 @@ func verifyHeaderUploadPart(header http.Header) error {
 	return nil
 }
 
+// uploadPart - Upload a single part and return its ETag.
+func uploadPart(config ServerConfig, bucketName, objectName, uploadID string, partNumber int, partData []byte) (string, error) {
+	req, err := newUploadPartReq(bucketName, objectName, uploadID, partNumber, partData)
+	if err != nil {
+		return "", err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return "", err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if err := uploadPartVerify(res, http.StatusOK); err != nil {
+		return "", err
+	}
+	return strings.Trim(res.Header.Get("ETag"), "\""), nil
+}
+
 // mainUploadPart - upload part test.
 func mainUploadPart(config ServerConfig, curTest int) bool {
 	message := fmt.Sprintf("[%02d/%d] Multipart (Upload-Part):", curTest, globalTotalNumTest)
@@ -160,6 +179,8 @@ func mainUploadPart(config ServerConfig, curTest int) bool {
 			printMessage(message, err)
 			return false
 		}
+		// Store the part as the body, each object is uploaded as a single part.
+		object.Body = objectData
 		// Update the ETag of the part.
 		part.ETag = strings.TrimPrefix(res.Header.Get("ETag"), "\"")
 		part.ETag = strings.TrimSuffix(part.ETag, "\"")
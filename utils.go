@@ -74,7 +74,7 @@ func printMessage(message string, err error) {
 
 // verifyHostReachable - Execute a simple get request against the provided endpoint to make sure its reachable.
 func verifyHostReachable(endpoint, region string) error {
-	targetURL, err := makeTargetURL(endpoint, "", "", region, nil)
+	targetURL, err := makeTargetURL(endpoint, "", "", region, nil, false)
 	if err != nil {
 		return err
 	}
@@ -148,7 +148,7 @@ func isAmazonEndpoint(endpointURL *url.URL) bool {
 }
 
 // Generate a new URL from the user provided endpoint.
-func makeTargetURL(endpoint, bucketName, objectName, region string, queryValues url.Values) (*url.URL, error) {
+func makeTargetURL(endpoint, bucketName, objectName, region string, queryValues url.Values, virtualHost bool) (*url.URL, error) {
 	targetURL, err := url.Parse(endpoint)
 	if err != nil {
 		return nil, err
@@ -157,7 +157,11 @@ func makeTargetURL(endpoint, bucketName, objectName, region string, queryValues
 		targetURL.Host = getS3Endpoint(region)
 	}
 	targetURL.Path = "/"
-	if bucketName != "" {
+	if bucketName != "" && virtualHost {
+		// Only website endpoints are addressed virtual host style.
+		targetURL.Host = bucketName + "." + targetURL.Host
+		targetURL.Path = "/" + objectName
+	} else if bucketName != "" {
 		targetURL.Path = "/" + bucketName + "/" + objectName // Use path style requests only.
 	}
 	if len(queryValues) > 0 { // If there are query values include them.
@@ -0,0 +1,240 @@
+/*
+ * Minio S3Verify Library for Amazon S3 Compatible Cloud Storage (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Header redirecting website requests for an object elsewhere.
+const websiteRedirectLocationHeader = "X-Amz-Website-Redirect-Location"
+
+// Holds all objects created by the website tests.
+var websiteObjects = []*ObjectInfo{}
+
+// newPutWebsiteObjectReq - Create a new HTTP request for PUT object served through the website endpoint.
+func newPutWebsiteObjectReq(bucketName string, object *ObjectInfo, redirectLocation string) (Request, error) {
+	putObjectReq, err := newPutObjectReq(bucketName, object.Key, object.Body)
+	if err != nil {
+		return Request{}, err
+	}
+	putObjectReq.customHeader.Set("Content-Type", "text/html")
+	if redirectLocation != "" {
+		putObjectReq.customHeader.Set(websiteRedirectLocationHeader, redirectLocation)
+	}
+	return putObjectReq, nil
+}
+
+// putWebsiteObject - Upload an object served through the website endpoint.
+func putWebsiteObject(config ServerConfig, bucketName string, object *ObjectInfo, redirectLocation string) error {
+	req, err := newPutWebsiteObjectReq(bucketName, object, redirectLocation)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := config.execRequest("PUT", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	return putObjectVerify(res, http.StatusOK)
+}
+
+// newWebsiteReq - Create a new anonymous HTTP request for a path of a bucket website.
+func newWebsiteReq(bucketName, path string) (Request, error) {
+	// websiteReq - an unsigned HTTP request addressing the bucket by its subdomain.
+	var websiteReq = Request{
+		customHeader: http.Header{},
+		anonymous:    true,
+		virtualHost:  true,
+	}
+
+	// Set the bucketName and the path as the objectName.
+	websiteReq.bucketName = bucketName
+	websiteReq.objectName = path
+
+	reader := bytes.NewReader([]byte{}) // Compute hash using empty body because GET requests do not send a body.
+	_, sha256Sum, _, err := computeHash(reader)
+	if err != nil {
+		return Request{}, err
+	}
+
+	// Set the headers.
+	websiteReq.customHeader.Set("X-Amz-Content-Sha256", hex.EncodeToString(sha256Sum))
+	websiteReq.customHeader.Set("User-Agent", appUserAgent)
+
+	return websiteReq, nil
+}
+
+// newWebsiteConfig - A copy of the server config sending requests to the website endpoint
+// without following redirects, so they can be verified.
+func newWebsiteConfig(config ServerConfig) ServerConfig {
+	websiteConfig := config
+	websiteConfig.Endpoint = config.WebsiteURL
+	websiteConfig.Client = &http.Client{
+		Transport: config.Client.Transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return websiteConfig
+}
+
+// getWebsitePath - GET a path of a bucket website and verify the status, body and redirect location returned.
+func getWebsitePath(websiteConfig ServerConfig, bucketName, path string, expectedStatusCode int, expectedBody []byte, expectedLocation string) error {
+	req, err := newWebsiteReq(bucketName, path)
+	if err != nil {
+		return err
+	}
+	// Execute the request.
+	res, err := websiteConfig.execRequest("GET", req)
+	if err != nil {
+		return err
+	}
+	defer closeResponse(res)
+	// Verify the response.
+	if res.StatusCode != expectedStatusCode {
+		err := fmt.Errorf("Unexpected Status Received: wanted %v, got %v", expectedStatusCode, res.StatusCode)
+		return err
+	}
+	// Redirects may be absolute or relative to the website.
+	if location := res.Header.Get("Location"); !strings.HasSuffix(location, expectedLocation) {
+		err := fmt.Errorf("Unexpected Location Received: wanted %v, got %v", expectedLocation, location)
+		return err
+	}
+	if expectedBody == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(body, expectedBody) {
+		err := fmt.Errorf("Unexpected Body Received: wanted %v, got %v", string(expectedBody), string(body))
+		return err
+	}
+	return nil
+}
+
+// mainWebsiteEndpoint - Entry point for the test of the index, error documents and redirects served by the website endpoint.
+func mainWebsiteEndpoint(config ServerConfig, curTest int) bool {
+	// Only servers exposing a website endpoint are tested against it, the test name reports when those checks are skipped.
+	testName := "Website Endpoint"
+	if config.WebsiteURL == "" {
+		testName = "Website Endpoint (Endpoint Skipped)"
+	}
+	message := fmt.Sprintf("[%02d/%d] %s:", curTest, globalTotalNumTest, testName)
+	// Spin scanBar
+	scanBar(message)
+	// The website was configured on the first s3verify created bucket by the PutBucketWebsite test.
+	bucketName := s3verifyBuckets[0].Name
+	index := &ObjectInfo{Key: "index.html", Body: []byte("<html>s3verify index</html>")}
+	docsIndex := &ObjectInfo{Key: "docs/index.html", Body: []byte("<html>s3verify docs</html>")}
+	errorDocument := &ObjectInfo{Key: "error.html", Body: []byte("<html>s3verify not found</html>")}
+	redirected := &ObjectInfo{Key: "redirected", Body: []byte{}}
+	// Save the objects so they are removed later.
+	websiteObjects = append(websiteObjects, index, docsIndex, errorDocument, redirected)
+	for _, object := range []*ObjectInfo{index, docsIndex, errorDocument} {
+		if err := putWebsiteObject(config, bucketName, object, ""); err != nil {
+			printMessage(message, err)
+			return false
+		}
+	}
+	if err := putWebsiteObject(config, bucketName, redirected, "/docs/"); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	// The redirect location is stored along with the object.
+	req, err := newHeadObjectReq(bucketName, redirected.Key)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	res, err := config.execRequest("HEAD", req)
+	if err != nil {
+		printMessage(message, err)
+		return false
+	}
+	defer closeResponse(res)
+	if err := headObjectVerify(res, http.StatusOK); err != nil {
+		printMessage(message, err)
+		return false
+	}
+	if location := res.Header.Get(websiteRedirectLocationHeader); location != "/docs/" {
+		err := fmt.Errorf("Unexpected %s Received: wanted %v, got %v", websiteRedirectLocationHeader, "/docs/", location)
+		printMessage(message, err)
+		return false
+	}
+	// Spin scanBar
+	scanBar(message)
+	if config.WebsiteURL == "" {
+		// Test passed.
+		printMessage(message, nil)
+		return true
+	}
+	// Website endpoints only serve objects anyone can read, a bucket policy makes them readable
+	// without relying on object ACLs, which buckets may have disabled.
+	if err := putBucketPolicy(config, bucketName, newPublicReadPolicy(bucketName), http.StatusOK, ErrorResponse{}); err != nil {
+		err := fmt.Errorf("%v (does the bucket block public policies?)", err)
+		printMessage(message, err)
+		return false
+	}
+	// Make the bucket private again once the test is over.
+	defer removeBucketPolicy(config, bucketName)
+	websiteConfig := newWebsiteConfig(config)
+	testCases := []struct {
+		path               string
+		expectedStatusCode int
+		expectedBody       []byte
+		expectedLocation   string
+	}{
+		// Directory style paths serve their index document.
+		{"", http.StatusOK, index.Body, ""},
+		{"docs/", http.StatusOK, docsIndex.Body, ""},
+		// A directory without its trailing slash is redirected to it.
+		{"docs", http.StatusFound, nil, "/docs/"},
+		// Missing objects serve the error document.
+		{"missing.html", http.StatusNotFound, errorDocument.Body, ""},
+		// Objects can redirect elsewhere.
+		{"redirected", http.StatusMovedPermanently, nil, "/docs/"},
+		// Routing rules.
+		{"old/page.html", http.StatusMovedPermanently, nil, "/new/page.html"},
+		{"gone/page.html", http.StatusFound, nil, "/index.html"},
+	}
+	for _, testCase := range testCases {
+		// Spin scanBar
+		scanBar(message)
+		if err := getWebsitePath(websiteConfig, bucketName, testCase.path, testCase.expectedStatusCode, testCase.expectedBody, testCase.expectedLocation); err != nil {
+			err := fmt.Errorf("GET /%s: %v", testCase.path, err)
+			printMessage(message, err)
+			return false
+		}
+	}
+	// Spin scanBar
+	scanBar(message)
+	// Test passed.
+	printMessage(message, nil)
+	return true
+}